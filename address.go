@@ -3,6 +3,7 @@ package iotago
 import (
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 
 	"github.com/iotaledger/hive.go/serializer"
@@ -35,6 +36,11 @@ const (
 	Ed25519AddressSerializedBytesSize = serializer.SmallTypeDenotationByteSize + Ed25519AddressBytesLength
 )
 
+var (
+	// ErrBech32NetworkPrefixMismatch gets returned if a bech32 encoded address has a different network prefix than expected.
+	ErrBech32NetworkPrefixMismatch = errors.New("bech32 network prefix mismatch")
+)
+
 // Address describes a general address.
 type Address interface {
 	serializer.Serializable
@@ -98,6 +104,35 @@ func ParseBech32(s string) (NetworkPrefix, Address, error) {
 	return NetworkPrefix(hrp), addr, nil
 }
 
+// ParseBech32Address decodes a bech32 encoded address and checks that its network prefix is hrp.
+// Errors of the bech32 decoding, like a checksum mismatch, wrap the corresponding errors of the bech32 package.
+func ParseBech32Address(hrp NetworkPrefix, s string) (Address, error) {
+	prefix, addr, err := ParseBech32(s)
+	if err != nil {
+		return nil, err
+	}
+	if prefix != hrp {
+		return nil, fmt.Errorf("%w: expected %s but got %s", ErrBech32NetworkPrefixMismatch, hrp, prefix)
+	}
+	return addr, nil
+}
+
+// Bech32AddressString encodes the given address as a bech32 string with the network prefix hrp.
+// Unlike Address.Bech32, it returns an error instead of panicking if the address can not be encoded.
+func Bech32AddressString(hrp NetworkPrefix, addr Address) (string, error) {
+	switch addr.(type) {
+	case *Ed25519Address:
+	default:
+		return "", fmt.Errorf("%w: can not bech32 encode address of type %T", ErrUnknownAddrType, addr)
+	}
+
+	addrBytes, err := addr.Serialize(serializer.DeSeriModePerformValidation)
+	if err != nil {
+		return "", err
+	}
+	return bech32.Encode(string(hrp), addrBytes)
+}
+
 // ParseEd25519AddressFromHexString parses the given hex string into an Ed25519Address.
 func ParseEd25519AddressFromHexString(hexAddr string) (*Ed25519Address, error) {
 	addrBytes, err := hex.DecodeString(hexAddr)
@@ -135,11 +170,17 @@ func (edAddr *Ed25519Address) String() string {
 	return hex.EncodeToString(edAddr[:])
 }
 
+// Size returns the size of the Ed25519Address in its serialized form.
+func (edAddr *Ed25519Address) Size() int {
+	return Ed25519AddressSerializedBytesSize
+}
+
 func (edAddr *Ed25519Address) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
+	// the length is checked regardless of the mode as the fixed size fields are sliced out of data
+	if err := serializer.CheckMinByteLength(Ed25519AddressSerializedBytesSize, len(data)); err != nil {
+		return 0, fmt.Errorf("invalid Ed25519 address bytes: %w", err)
+	}
 	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
-		if err := serializer.CheckMinByteLength(Ed25519AddressSerializedBytesSize, len(data)); err != nil {
-			return 0, fmt.Errorf("invalid Ed25519 address bytes: %w", err)
-		}
 		if err := serializer.CheckTypeByte(data, AddressEd25519); err != nil {
 			return 0, fmt.Errorf("unable to deserialize Ed25519 address: %w", err)
 		}
@@ -155,6 +196,12 @@ func (edAddr *Ed25519Address) Serialize(deSeriMode serializer.DeSerializationMod
 	return b[:], nil
 }
 
+// appends the serialized form of the Ed25519Address to dst, which equals the output of Serialize().
+func (edAddr *Ed25519Address) appendSerialized(dst []byte) []byte {
+	dst = append(dst, AddressEd25519)
+	return append(dst, edAddr[:]...)
+}
+
 func (edAddr *Ed25519Address) MarshalJSON() ([]byte, error) {
 	jEd25519Address := &jsonEd25519Address{}
 	jEd25519Address.Address = hex.EncodeToString(edAddr[:])
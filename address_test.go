@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/bech32"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -107,3 +108,33 @@ func TestParseBech32(t *testing.T) {
 		})
 	}
 }
+
+func TestBech32AddressRoundTrip(t *testing.T) {
+	for _, tt := range bech32Tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := iotago.Bech32AddressString(tt.network, tt.addr)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.bech32, s)
+
+			addr, err := iotago.ParseBech32Address(tt.network, s)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.addr, addr)
+		})
+	}
+
+	t.Run("err - network prefix mismatch", func(t *testing.T) {
+		_, err := iotago.ParseBech32Address(iotago.PrefixTestnet, bech32Tests[0].bech32)
+		assert.True(t, errors.Is(err, iotago.ErrBech32NetworkPrefixMismatch))
+	})
+
+	t.Run("err - checksum mismatch", func(t *testing.T) {
+		s := []byte(bech32Tests[0].bech32)
+		if s[len(s)-1] == 'q' {
+			s[len(s)-1] = 'p'
+		} else {
+			s[len(s)-1] = 'q'
+		}
+		_, err := iotago.ParseBech32Address(bech32Tests[0].network, string(s))
+		assert.True(t, errors.Is(err, bech32.ErrInvalidChecksum))
+	})
+}
@@ -61,6 +61,25 @@ func BenchmarkSerializeWithoutValidationOneIOTxPayload(b *testing.B) {
 	}
 }
 
+func BenchmarkSerializeIntoWithoutValidationOneIOTxEssence(b *testing.B) {
+	txEssence := tpkg.OneInputOutputTransaction().Essence.(*iotago.TransactionEssence)
+	buf := make([]byte, 0, iotago.MessageBinSerializedMaxSize)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf, _ = txEssence.SerializeInto(buf[:0], serializer.DeSeriModeNoValidation)
+	}
+}
+
+func BenchmarkSigningMessageOneIOTxEssence(b *testing.B) {
+	txEssence := tpkg.OneInputOutputTransaction().Essence.(*iotago.TransactionEssence)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		txEssence.SigningMessage()
+	}
+}
+
 func BenchmarkSignEd25519OneIOTxEssence(b *testing.B) {
 	txPayload := tpkg.OneInputOutputTransaction()
 	b.ResetTimer()
@@ -0,0 +1,335 @@
+package iotago
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// The CBOR (RFC 8949) representation of an object is derived from its JSON representation:
+// JSON objects become CBOR maps with text string keys in canonical order, JSON arrays become CBOR arrays,
+// JSON strings become CBOR text strings and JSON numbers become CBOR integers or floats.
+// The hex encoded binary fields of the JSON representation, listed in cborByteStringFields, become CBOR byte strings.
+// This way the CBOR representation carries the same type fields as the JSON one and is decoded via the same
+// ToSerializable functions. Like JSON decoding, CBOR decoding does not syntactically validate the produced object.
+
+const (
+	cborMajorUint       = 0
+	cborMajorNegInt     = 1
+	cborMajorByteString = 2
+	cborMajorTextString = 3
+	cborMajorArray      = 4
+	cborMajorMap        = 5
+	cborMajorSimple     = 7
+
+	cborSimpleFalse   = 20
+	cborSimpleTrue    = 21
+	cborSimpleNull    = 22
+	cborSimpleFloat64 = 27
+
+	// the max nesting depth of arrays and maps accepted while decoding.
+	cborMaxNestingDepth = 32
+)
+
+var (
+	// ErrInvalidCBOR gets returned when data can not be decoded from CBOR or an object can not be encoded to it.
+	ErrInvalidCBOR = errors.New("invalid CBOR")
+)
+
+// the keys of the JSON fields holding hex encoded bytes, which are encoded as CBOR byte strings.
+// Fields with these keys holding objects instead of strings, like the address of an output, are left as is.
+var cborByteStringFields = map[string]bool{
+	"transactionId": true,
+	"address":       true,
+	"publicKey":     true,
+	"signature":     true,
+	"index":         true,
+	"data":          true,
+}
+
+// MarshalCBOR encodes the Transaction into its CBOR representation.
+func (t *Transaction) MarshalCBOR() ([]byte, error) {
+	return jsonMarshalerToCBOR(t)
+}
+
+// UnmarshalCBOR decodes the Transaction from its CBOR representation.
+// The decoded Transaction is not syntactically validated.
+func (t *Transaction) UnmarshalCBOR(data []byte) error {
+	return cborToJSONUnmarshaler(data, t)
+}
+
+// MarshalCBOR encodes the TransactionEssence into its CBOR representation.
+func (u *TransactionEssence) MarshalCBOR() ([]byte, error) {
+	return jsonMarshalerToCBOR(u)
+}
+
+// UnmarshalCBOR decodes the TransactionEssence from its CBOR representation.
+// The decoded TransactionEssence is not syntactically validated.
+func (u *TransactionEssence) UnmarshalCBOR(data []byte) error {
+	return cborToJSONUnmarshaler(data, u)
+}
+
+// encodes the JSON representation of the given object to CBOR.
+func jsonMarshalerToCBOR(m json.Marshaler) ([]byte, error) {
+	v, err := jsonGenericValue(m)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err := cborEncode(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodes the given CBOR data and passes its JSON equivalent to the given object.
+func cborToJSONUnmarshaler(data []byte, u json.Unmarshaler) error {
+	v, rest, err := cborDecode(data, 0)
+	if err != nil {
+		return err
+	}
+	if len(rest) != 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidCBOR, len(rest))
+	}
+	jsonBytes, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return u.UnmarshalJSON(jsonBytes)
+}
+
+// writes the CBOR encoding of the given JSON value.
+func cborEncode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(cborMajorSimple<<5 | cborSimpleNull)
+	case bool:
+		if v {
+			buf.WriteByte(cborMajorSimple<<5 | cborSimpleTrue)
+			break
+		}
+		buf.WriteByte(cborMajorSimple<<5 | cborSimpleFalse)
+	case string:
+		cborWriteHead(buf, cborMajorTextString, uint64(len(v)))
+		buf.WriteString(v)
+	case json.Number:
+		if n, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+			cborWriteHead(buf, cborMajorUint, n)
+			break
+		}
+		if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			cborWriteHead(buf, cborMajorNegInt, uint64(-(n + 1)))
+			break
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return fmt.Errorf("%w: number %s", ErrInvalidCBOR, v)
+		}
+		buf.WriteByte(cborMajorSimple<<5 | cborSimpleFloat64)
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], math.Float64bits(f))
+		buf.Write(b[:])
+	case []interface{}:
+		cborWriteHead(buf, cborMajorArray, uint64(len(v)))
+		for _, elem := range v {
+			if err := cborEncode(buf, elem); err != nil {
+				return err
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		// canonical CBOR orders keys by their length first and then lexicographically
+		sort.Slice(keys, func(i, j int) bool {
+			if len(keys[i]) != len(keys[j]) {
+				return len(keys[i]) < len(keys[j])
+			}
+			return keys[i] < keys[j]
+		})
+		cborWriteHead(buf, cborMajorMap, uint64(len(v)))
+		for _, k := range keys {
+			cborWriteHead(buf, cborMajorTextString, uint64(len(k)))
+			buf.WriteString(k)
+			if hexStr, isStr := v[k].(string); isStr && cborByteStringFields[k] {
+				b, err := hex.DecodeString(hexStr)
+				if err != nil {
+					return fmt.Errorf("%w: field %s is not hex encoded: %v", ErrInvalidCBOR, k, err)
+				}
+				cborWriteHead(buf, cborMajorByteString, uint64(len(b)))
+				buf.Write(b)
+				continue
+			}
+			if err := cborEncode(buf, v[k]); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unsupported value of type %T", ErrInvalidCBOR, v)
+	}
+	return nil
+}
+
+// writes the head of a CBOR data item in its shortest form.
+func cborWriteHead(buf *bytes.Buffer, major byte, arg uint64) {
+	switch {
+	case arg < 24:
+		buf.WriteByte(major<<5 | byte(arg))
+	case arg <= math.MaxUint8:
+		buf.WriteByte(major<<5 | 24)
+		buf.WriteByte(byte(arg))
+	case arg <= math.MaxUint16:
+		buf.WriteByte(major<<5 | 25)
+		var b [2]byte
+		binary.BigEndian.PutUint16(b[:], uint16(arg))
+		buf.Write(b[:])
+	case arg <= math.MaxUint32:
+		buf.WriteByte(major<<5 | 26)
+		var b [4]byte
+		binary.BigEndian.PutUint32(b[:], uint32(arg))
+		buf.Write(b[:])
+	default:
+		buf.WriteByte(major<<5 | 27)
+		var b [8]byte
+		binary.BigEndian.PutUint64(b[:], arg)
+		buf.Write(b[:])
+	}
+}
+
+// reads the head of a CBOR data item and returns its major type, argument and the remaining data.
+func cborReadHead(data []byte) (byte, uint64, []byte, error) {
+	if len(data) == 0 {
+		return 0, 0, nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidCBOR)
+	}
+	major, info := data[0]>>5, data[0]&0x1f
+	data = data[1:]
+	var argLen int
+	switch {
+	case info < 24:
+		return major, uint64(info), data, nil
+	case info == 24:
+		argLen = 1
+	case info == 25:
+		argLen = 2
+	case info == 26:
+		argLen = 4
+	case info == 27:
+		argLen = 8
+	default:
+		return 0, 0, nil, fmt.Errorf("%w: unsupported additional information %d", ErrInvalidCBOR, info)
+	}
+	if len(data) < argLen {
+		return 0, 0, nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidCBOR)
+	}
+	var arg uint64
+	for _, b := range data[:argLen] {
+		arg = arg<<8 | uint64(b)
+	}
+	return major, arg, data[argLen:], nil
+}
+
+// decodes a single CBOR data item into its JSON value and returns the remaining data.
+func cborDecode(data []byte, depth int) (interface{}, []byte, error) {
+	if depth > cborMaxNestingDepth {
+		return nil, nil, fmt.Errorf("%w: max nesting depth of %d exceeded", ErrInvalidCBOR, cborMaxNestingDepth)
+	}
+
+	var info byte
+	if len(data) > 0 {
+		info = data[0] & 0x1f
+	}
+	major, arg, data, err := cborReadHead(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch major {
+	case cborMajorUint:
+		return json.Number(strconv.FormatUint(arg, 10)), data, nil
+	case cborMajorNegInt:
+		if arg > math.MaxInt64 {
+			return nil, nil, fmt.Errorf("%w: negative integer out of range", ErrInvalidCBOR)
+		}
+		return json.Number(strconv.FormatInt(-1-int64(arg), 10)), data, nil
+	case cborMajorByteString:
+		b, rest, err := cborReadBytes(data, arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return hex.EncodeToString(b), rest, nil
+	case cborMajorTextString:
+		s, rest, err := cborReadBytes(data, arg)
+		if err != nil {
+			return nil, nil, err
+		}
+		return string(s), rest, nil
+	case cborMajorArray:
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: array length %d exceeds data", ErrInvalidCBOR, arg)
+		}
+		arr := make([]interface{}, arg)
+		for i := range arr {
+			if arr[i], data, err = cborDecode(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+		}
+		return arr, data, nil
+	case cborMajorMap:
+		if arg > uint64(len(data)) {
+			return nil, nil, fmt.Errorf("%w: map length %d exceeds data", ErrInvalidCBOR, arg)
+		}
+		m := make(map[string]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			var keyMajor byte
+			var keyLen uint64
+			if keyMajor, keyLen, data, err = cborReadHead(data); err != nil {
+				return nil, nil, err
+			}
+			if keyMajor != cborMajorTextString {
+				return nil, nil, fmt.Errorf("%w: map key of major type %d instead of text string", ErrInvalidCBOR, keyMajor)
+			}
+			var key []byte
+			if key, data, err = cborReadBytes(data, keyLen); err != nil {
+				return nil, nil, err
+			}
+			if m[string(key)], data, err = cborDecode(data, depth+1); err != nil {
+				return nil, nil, err
+			}
+		}
+		return m, data, nil
+	case cborMajorSimple:
+		// simple values are only accepted in their single byte form and floats only as float64
+		switch info {
+		case cborSimpleFalse:
+			return false, data, nil
+		case cborSimpleTrue:
+			return true, data, nil
+		case cborSimpleNull:
+			return nil, data, nil
+		case cborSimpleFloat64:
+			f := math.Float64frombits(arg)
+			if math.IsNaN(f) || math.IsInf(f, 0) {
+				return nil, nil, fmt.Errorf("%w: float %v has no JSON representation", ErrInvalidCBOR, f)
+			}
+			return json.Number(strconv.FormatFloat(f, 'g', -1, 64)), data, nil
+		}
+		return nil, nil, fmt.Errorf("%w: unsupported simple value or float with additional information %d", ErrInvalidCBOR, info)
+	default:
+		return nil, nil, fmt.Errorf("%w: unsupported major type %d", ErrInvalidCBOR, major)
+	}
+}
+
+// reads n bytes from data and returns them and the remaining data.
+func cborReadBytes(data []byte, n uint64) ([]byte, []byte, error) {
+	if n > uint64(len(data)) {
+		return nil, nil, fmt.Errorf("%w: unexpected end of data", ErrInvalidCBOR)
+	}
+	return data[:n], data[n:], nil
+}
@@ -0,0 +1,200 @@
+package iotago
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/stretchr/testify/assert"
+)
+
+// lengths covering the argument classes of a CBOR head up to 4 bytes: immediate, 1, 2 and 4 bytes.
+// The 8 byte class is covered by integers only, as strings, arrays and maps of such lengths do not fit into memory.
+var cborLengthClasses = []uint64{0, 23, 24, math.MaxUint8, math.MaxUint8 + 1, math.MaxUint16, math.MaxUint16 + 1}
+
+// returns the size of the head of a CBOR data item with the given argument.
+func cborHeadSize(arg uint64) int {
+	switch {
+	case arg < 24:
+		return 1
+	case arg <= math.MaxUint8:
+		return 2
+	case arg <= math.MaxUint16:
+		return 3
+	case arg <= math.MaxUint32:
+		return 5
+	default:
+		return 9
+	}
+}
+
+func TestCBOR_RoundTrip(t *testing.T) {
+	type test struct {
+		name  string
+		value interface{}
+		major byte
+		arg   uint64
+	}
+
+	var tests []test
+	for _, n := range append(cborLengthClasses, math.MaxUint32, math.MaxUint32+1, math.MaxUint64) {
+		tests = append(tests, test{name: "uint " + strconv.FormatUint(n, 10), value: json.Number(strconv.FormatUint(n, 10)), major: cborMajorUint, arg: n})
+	}
+	for _, n := range append(cborLengthClasses, math.MaxUint32, math.MaxUint32+1, math.MaxInt64) {
+		v := -1 - int64(n)
+		tests = append(tests, test{name: "negative int " + strconv.FormatInt(v, 10), value: json.Number(strconv.FormatInt(v, 10)), major: cborMajorNegInt, arg: n})
+	}
+	for _, n := range cborLengthClasses {
+		tests = append(tests, test{name: "text string of length " + strconv.FormatUint(n, 10), value: strings.Repeat("a", int(n)), major: cborMajorTextString, arg: n})
+
+		arr := make([]interface{}, n)
+		for i := range arr {
+			arr[i] = true
+		}
+		tests = append(tests, test{name: "array of length " + strconv.FormatUint(n, 10), value: arr, major: cborMajorArray, arg: n})
+
+		m := make(map[string]interface{}, n)
+		for i := uint64(0); i < n; i++ {
+			m[strconv.FormatUint(i, 10)] = nil
+		}
+		tests = append(tests, test{name: "map of size " + strconv.FormatUint(n, 10), value: m, major: cborMajorMap, arg: n})
+	}
+	tests = append(tests,
+		test{name: "false", value: false, major: cborMajorSimple, arg: cborSimpleFalse},
+		test{name: "true", value: true, major: cborMajorSimple, arg: cborSimpleTrue},
+		test{name: "null", value: nil, major: cborMajorSimple, arg: cborSimpleNull},
+		test{name: "float", value: json.Number("1.5"), major: cborMajorSimple, arg: math.Float64bits(1.5)},
+	)
+
+	nested := interface{}(true)
+	for i := 0; i < cborMaxNestingDepth; i++ {
+		nested = []interface{}{nested}
+	}
+	tests = append(tests, test{name: "max nesting depth", value: nested, major: cborMajorArray, arg: 1})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			assert.NoError(t, cborEncode(&buf, tt.value))
+
+			major, arg, _, err := cborReadHead(buf.Bytes())
+			assert.NoError(t, err)
+			assert.Equal(t, tt.major, major)
+			assert.Equal(t, tt.arg, arg)
+
+			decoded, rest, err := cborDecode(buf.Bytes(), 0)
+			assert.NoError(t, err)
+			assert.Empty(t, rest)
+			assert.Equal(t, tt.value, decoded)
+		})
+	}
+}
+
+func TestCBOR_ByteStrings(t *testing.T) {
+	for _, n := range cborLengthClasses {
+		t.Run("byte string of length "+strconv.FormatUint(n, 10), func(t *testing.T) {
+			value := map[string]interface{}{"data": hex.EncodeToString(bytes.Repeat([]byte{0xab}, int(n)))}
+
+			var buf bytes.Buffer
+			assert.NoError(t, cborEncode(&buf, value))
+
+			// map head, "data" key, byte string head and the raw bytes
+			encoded := buf.Bytes()[1+1+len("data"):]
+			major, arg, rest, err := cborReadHead(encoded)
+			assert.NoError(t, err)
+			assert.EqualValues(t, cborMajorByteString, major)
+			assert.Equal(t, n, arg)
+			assert.Len(t, encoded, cborHeadSize(n)+int(n))
+			assert.Equal(t, bytes.Repeat([]byte{0xab}, int(n)), rest)
+
+			decoded, _, err := cborDecode(buf.Bytes(), 0)
+			assert.NoError(t, err)
+			assert.Equal(t, value, decoded)
+		})
+	}
+
+	t.Run("byte string fields holding objects are left as is", func(t *testing.T) {
+		value := map[string]interface{}{"address": map[string]interface{}{"type": json.Number("0")}}
+		var buf bytes.Buffer
+		assert.NoError(t, cborEncode(&buf, value))
+		decoded, _, err := cborDecode(buf.Bytes(), 0)
+		assert.NoError(t, err)
+		assert.Equal(t, value, decoded)
+	})
+
+	t.Run("err - byte string field is not hex encoded", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := cborEncode(&buf, map[string]interface{}{"data": "not hex"})
+		assert.True(t, errors.Is(err, ErrInvalidCBOR))
+	})
+}
+
+func TestCBOR_Malformed(t *testing.T) {
+	maxArg := []byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{name: "empty", data: []byte{}},
+		{name: "truncated 1 byte argument", data: []byte{0x18}},
+		{name: "truncated 2 byte argument", data: []byte{0x19, 0x01}},
+		{name: "truncated 4 byte argument", data: []byte{0x1a, 0x01, 0x02, 0x03}},
+		{name: "truncated 8 byte argument", data: []byte{0x1b, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07}},
+		{name: "reserved additional information", data: []byte{0x1c}},
+		{name: "indefinite length byte string", data: []byte{0x5f, 0x41, 0x01, 0xff}},
+		{name: "indefinite length array", data: []byte{0x9f, 0x01, 0xff}},
+		{name: "negative int out of range", data: append([]byte{0x3b}, maxArg...)},
+		{name: "truncated byte string", data: []byte{0x43, 0x01, 0x02}},
+		{name: "oversized byte string", data: append([]byte{0x5b}, maxArg...)},
+		{name: "truncated text string", data: []byte{0x63, 'a', 'b'}},
+		{name: "oversized text string", data: append([]byte{0x7b}, maxArg...)},
+		{name: "truncated array", data: []byte{0x82, 0x01}},
+		{name: "oversized array", data: append([]byte{0x9b}, maxArg...)},
+		{name: "truncated map value", data: []byte{0xa1, 0x61, 'a'}},
+		{name: "truncated map key", data: []byte{0xa1, 0x62, 'a'}},
+		{name: "oversized map", data: append([]byte{0xbb}, maxArg...)},
+		{name: "map key which is no text string", data: []byte{0xa1, 0x01, 0x01}},
+		{name: "tag", data: []byte{0xc0, 0x01}},
+		{name: "undefined", data: []byte{0xf7}},
+		{name: "two byte simple value", data: []byte{0xf8, cborSimpleTrue}},
+		{name: "half float", data: []byte{0xf9, 0x3c, 0x00}},
+		{name: "single float", data: []byte{0xfa, 0x3f, 0xc0, 0x00, 0x00}},
+		{name: "NaN", data: []byte{0xfb, 0x7f, 0xf8, 0, 0, 0, 0, 0, 0}},
+		{name: "nesting depth exceeded", data: append(bytes.Repeat([]byte{0x81}, cborMaxNestingDepth+1), 0x01)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := cborDecode(tt.data, 0)
+			assert.True(t, errors.Is(err, ErrInvalidCBOR), err)
+		})
+	}
+
+	t.Run("trailing bytes", func(t *testing.T) {
+		assert.True(t, errors.Is(cborToJSONUnmarshaler([]byte{0xa0, 0x00}, &Transaction{}), ErrInvalidCBOR))
+	})
+
+	t.Run("truncated transaction", func(t *testing.T) {
+		addr := &Ed25519Address{}
+		tx := &Transaction{
+			Essence: &TransactionEssence{
+				Inputs:  serializer.Serializables{&UTXOInput{}},
+				Outputs: serializer.Serializables{&SigLockedSingleOutput{Address: addr, Amount: 1337}},
+				Payload: &Indexation{Index: []byte("index"), Data: []byte("data")},
+			},
+			UnlockBlocks: serializer.Serializables{&SignatureUnlockBlock{Signature: &Ed25519Signature{}}},
+		}
+		data, err := tx.MarshalCBOR()
+		assert.NoError(t, err)
+		for i := 0; i < len(data); i++ {
+			assert.True(t, errors.Is((&Transaction{}).UnmarshalCBOR(data[:i]), ErrInvalidCBOR), "truncated to %d bytes", i)
+		}
+		assert.NoError(t, (&Transaction{}).UnmarshalCBOR(data))
+	})
+}
@@ -2,6 +2,8 @@ package iotago
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 )
 
 var (
@@ -24,3 +26,117 @@ var (
 	// ErrUnknownSignatureType gets returned for unknown signature types.
 	ErrUnknownSignatureType = errors.New("unknown signature type")
 )
+
+// DeserializationError wraps the error which made the deserialization of an object fail
+// together with the byte offset at which it failed.
+// When a nested object fails to deserialize, the offsets of the enclosing objects are added up,
+// so that Offset is relative to the start of the data passed to the outermost Deserialize() call.
+type DeserializationError struct {
+	// The byte offset at which the deserialization failed.
+	Offset int
+	// The error which made the deserialization fail.
+	Err error
+}
+
+func (e *DeserializationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *DeserializationError) Unwrap() error {
+	return e.Err
+}
+
+// wraps the given error into a DeserializationError at the given offset,
+// adding the offset of any DeserializationError of a nested object.
+func deserializationError(offset int, err error) error {
+	if err == nil {
+		return nil
+	}
+	var nestedErr *DeserializationError
+	if errors.As(err, &nestedErr) {
+		offset += nestedErr.Offset
+	}
+	return &DeserializationError{Offset: offset, Err: err}
+}
+
+// SemanticError wraps an error which made the semantic validation of a Transaction fail
+// together with the index of the input or output it was caused by.
+// Indices which do not apply to the error are set to -1.
+type SemanticError struct {
+	// The index of the input which caused the error or -1.
+	InputIndex int
+	// The index of the output which caused the error or -1.
+	OutputIndex int
+	// The error which made the semantic validation fail.
+	Err error
+}
+
+func (e *SemanticError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *SemanticError) Unwrap() error {
+	return e.Err
+}
+
+// wraps the given error into a SemanticError caused by the input at the given index.
+func inputSemanticError(index int, err error) error {
+	return &SemanticError{InputIndex: index, OutputIndex: -1, Err: err}
+}
+
+// wraps the given error into a SemanticError caused by the output at the given index.
+func outputSemanticError(index int, err error) error {
+	return &SemanticError{InputIndex: -1, OutputIndex: index, Err: err}
+}
+
+// SemanticValidationErrors aggregates all errors found by Transaction.SemanticallyValidateAll(),
+// grouped by the stage of the semantic validation which produced them.
+type SemanticValidationErrors struct {
+	// Errors regarding the deposits of the outputs and the balance of inputs and outputs.
+	Balance []error
+	// Errors returned by the SemanticValidationFunc(s), in the order in which the functions were passed.
+	SemanticValidationFuncs []error
+	// Errors of invalid signatures, in input order.
+	Signatures []error
+}
+
+// Errors returns all errors of all groups in a single slice.
+func (e *SemanticValidationErrors) Errors() []error {
+	errs := make([]error, 0, len(e.Balance)+len(e.SemanticValidationFuncs)+len(e.Signatures))
+	errs = append(errs, e.Balance...)
+	errs = append(errs, e.SemanticValidationFuncs...)
+	return append(errs, e.Signatures...)
+}
+
+func (e *SemanticValidationErrors) Error() string {
+	errs := e.Errors()
+	msgs := make([]string, len(errs))
+	for i, err := range errs {
+		msgs[i] = err.Error()
+	}
+	return fmt.Sprintf("%d semantic validation error(s): %s", len(errs), strings.Join(msgs, "; "))
+}
+
+// Is tells whether any of the aggregated errors matches target.
+func (e *SemanticValidationErrors) Is(target error) bool {
+	for _, err := range e.Errors() {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first of the aggregated errors which matches target.
+func (e *SemanticValidationErrors) As(target interface{}) bool {
+	for _, err := range e.Errors() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *SemanticValidationErrors) empty() bool {
+	return len(e.Balance) == 0 && len(e.SemanticValidationFuncs) == 0 && len(e.Signatures) == 0
+}
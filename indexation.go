@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 
 	"github.com/iotaledger/hive.go/serializer"
 )
@@ -36,8 +37,13 @@ type Indexation struct {
 	Data []byte `json:"data"`
 }
 
+// Size returns the size of the Indexation in its serialized form.
+func (u *Indexation) Size() int {
+	return serializer.TypeDenotationByteSize + serializer.UInt16ByteSize + len(u.Index) + serializer.UInt32ByteSize + len(u.Data)
+}
+
 func (u *Indexation) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(IndexationBinSerializedMinSize, len(data)); err != nil {
@@ -68,6 +74,7 @@ func (u *Indexation) Deserialize(data []byte, deSeriMode serializer.DeSerializat
 			return fmt.Errorf("unable to deserialize indexation data: %w", err)
 		}, MessageBinSerializedMaxSize). // obviously can never be that size
 		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (u *Indexation) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
@@ -98,6 +105,29 @@ func (u *Indexation) Serialize(deSeriMode serializer.DeSerializationMode) ([]byt
 		Serialize()
 }
 
+// appends the serialized form of the Indexation to dst, which equals the output of Serialize().
+func (u *Indexation) appendSerialized(dst []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+		switch {
+		case len(u.Index) > IndexationIndexMaxLength:
+			return dst, fmt.Errorf("unable to serialize indexation index: %w", ErrIndexationIndexExceedsMaxSize)
+		case len(u.Index) < IndexationIndexMinLength:
+			return dst, fmt.Errorf("unable to serialize indexation index: %w", ErrIndexationIndexUnderMinSize)
+		}
+	}
+	if len(u.Index) > math.MaxUint16 {
+		return dst, fmt.Errorf("unable to serialize indexation index: length %d is out of range (0-%d)", len(u.Index), math.MaxUint16)
+	}
+	if uint64(len(u.Data)) > math.MaxUint32 {
+		return dst, fmt.Errorf("unable to serialize indexation data: length %d is out of range (0-%d)", len(u.Data), uint64(math.MaxUint32))
+	}
+	dst = appendUint32(dst, IndexationPayloadTypeID)
+	dst = appendUint16(dst, uint16(len(u.Index)))
+	dst = append(dst, u.Index...)
+	dst = appendUint32(dst, uint32(len(u.Data)))
+	return append(dst, u.Data...), nil
+}
+
 func (u *Indexation) MarshalJSON() ([]byte, error) {
 	jIndexation := &jsonIndexation{}
 	jIndexation.Type = int(IndexationPayloadTypeID)
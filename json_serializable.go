@@ -1,6 +1,7 @@
 package iotago
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"github.com/iotaledger/hive.go/serializer"
@@ -56,3 +57,30 @@ func DeserializeObjectFromJSON(raw *json.RawMessage, selector JSONSerializableSe
 
 	return obj, nil
 }
+
+// decodes the JSON representation of the given object into its generic form of maps, slices, strings,
+// json.Number(s), bools and nils. Numbers are kept as json.Number to not lose the precision of big integers.
+func jsonGenericValue(m json.Marshaler) (interface{}, error) {
+	jsonBytes, err := m.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(jsonBytes))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// encodes the JSON representation of the given object canonically:
+// object keys are sorted and no insignificant whitespace is emitted.
+func canonicalJSON(m json.Marshaler) ([]byte, error) {
+	v, err := jsonGenericValue(m)
+	if err != nil {
+		return nil, err
+	}
+	// json.Marshal sorts map keys
+	return json.Marshal(v)
+}
@@ -26,11 +26,46 @@ const (
 var (
 	// ErrDepositAmountMustBeGreaterThanZero returned if the deposit amount of an output is less or equal zero.
 	ErrDepositAmountMustBeGreaterThanZero = errors.New("deposit amount must be greater than zero")
+	// ErrOutputDepositExceedsMax gets returned if an output deposits more than the configured maximum individual deposit.
+	ErrOutputDepositExceedsMax = errors.New("output deposit exceeds the maximum individual deposit")
 )
 
 // Outputs is a slice of Output.
 type Outputs []Output
 
+// OutputSet is a set of Output(s) identified by the UTXOInputID which references them.
+// It can be passed wherever an InputToOutputMapping is expected.
+type OutputSet map[UTXOInputID]Output
+
+// Filter returns a new OutputSet holding the Output(s) of the OutputSet for which pred returns true.
+func (outputSet OutputSet) Filter(pred func(utxoInputID UTXOInputID, output Output) bool) OutputSet {
+	filtered := make(OutputSet)
+	for utxoInputID, output := range outputSet {
+		if pred(utxoInputID, output) {
+			filtered[utxoInputID] = output
+		}
+	}
+	return filtered
+}
+
+// SumDeposit returns the sum of the deposits of the Output(s) of the OutputSet.
+// It returns ErrOutputDepositsMoreThanTotalSupply if the sum exceeds the total supply.
+func (outputSet OutputSet) SumDeposit() (uint64, error) {
+	var sum uint64
+	for utxoInputID, output := range outputSet {
+		deposit, err := output.Deposit()
+		if err != nil {
+			return 0, fmt.Errorf("unable to get deposit of output %v: %w", utxoInputID, err)
+		}
+		// checking against the remaining supply also rules out an overflow of sum
+		if deposit > TokenSupply-sum {
+			return 0, fmt.Errorf("%w: sum of deposits exceeds the total supply at output %v", ErrOutputDepositsMoreThanTotalSupply, utxoInputID)
+		}
+		sum += deposit
+	}
+	return sum, nil
+}
+
 // Output defines the deposit of funds.
 type Output interface {
 	serializer.Serializable
@@ -182,6 +217,20 @@ func OutputsDepositAmountValidator() OutputsValidatorFunc {
 	}
 }
 
+// OutputsPredicateMaxIndividualDeposit returns a predicate which checks that no output deposits more than the given max.
+func OutputsPredicateMaxIndividualDeposit(max uint64) OutputsValidatorFunc {
+	return func(index int, dep Output) error {
+		deposit, err := dep.Deposit()
+		if err != nil {
+			return fmt.Errorf("unable to get deposit of output: %w", err)
+		}
+		if deposit > max {
+			return fmt.Errorf("%w: output %d deposits %d, max is %d", ErrOutputDepositExceedsMax, index, deposit, max)
+		}
+		return nil
+	}
+}
+
 // supposed to be called with -1 as input in order to be used over multiple calls.
 var outputAmountValidator = OutputsDepositAmountValidator()
 
@@ -162,6 +162,32 @@ func TestOutputsValidatorFunc(t *testing.T) {
 				},
 			}, funcs: []iotago.OutputsValidatorFunc{iotago.OutputsDepositAmountValidator()}}, true,
 		},
+		{
+			"ok - deposit at max individual deposit",
+			args{outputs: []serializer.Serializable{
+				&iotago.SigLockedSingleOutput{
+					Address: nil,
+					Amount:  1_000,
+				},
+				&iotago.SigLockedSingleOutput{
+					Address: nil,
+					Amount:  1_000,
+				},
+			}, funcs: []iotago.OutputsValidatorFunc{iotago.OutputsPredicateMaxIndividualDeposit(1_000)}}, false,
+		},
+		{
+			"deposit over max individual deposit",
+			args{outputs: []serializer.Serializable{
+				&iotago.SigLockedSingleOutput{
+					Address: nil,
+					Amount:  1_000,
+				},
+				&iotago.SigLockedSingleOutput{
+					Address: nil,
+					Amount:  1_001,
+				},
+			}, funcs: []iotago.OutputsValidatorFunc{iotago.OutputsPredicateMaxIndividualDeposit(1_000)}}, true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -171,3 +197,54 @@ func TestOutputsValidatorFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputSet_Filter(t *testing.T) {
+	addrA, _ := tpkg.RandEd25519Address()
+	addrB, _ := tpkg.RandEd25519Address()
+	utxoInputA := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	utxoInputB := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 1}
+	outputA := &iotago.SigLockedSingleOutput{Address: addrA, Amount: 100}
+	outputSet := iotago.OutputSet{
+		utxoInputA.ID(): outputA,
+		utxoInputB.ID(): &iotago.SigLockedSingleOutput{Address: addrB, Amount: 200},
+	}
+
+	filtered := outputSet.Filter(func(_ iotago.UTXOInputID, output iotago.Output) bool {
+		target, _ := output.Target()
+		return *target.(*iotago.Ed25519Address) == *addrA
+	})
+	assert.Equal(t, iotago.OutputSet{utxoInputA.ID(): outputA}, filtered)
+	assert.Len(t, outputSet, 2)
+}
+
+func TestOutputSet_SumDeposit(t *testing.T) {
+	tests := []struct {
+		name    string
+		amounts []uint64
+		sum     uint64
+		err     error
+	}{
+		{name: "ok - empty", sum: 0},
+		{name: "ok", amounts: []uint64{100, 200}, sum: 300},
+		{name: "ok - total supply", amounts: []uint64{iotago.TokenSupply - 100, 100}, sum: iotago.TokenSupply},
+		{name: "err - exceeds total supply", amounts: []uint64{iotago.TokenSupply, 1}, err: iotago.ErrOutputDepositsMoreThanTotalSupply},
+		{name: "err - would overflow", amounts: []uint64{^uint64(0), ^uint64(0)}, err: iotago.ErrOutputDepositsMoreThanTotalSupply},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, _ := tpkg.RandEd25519Address()
+			outputSet := iotago.OutputSet{}
+			for i, amount := range tt.amounts {
+				utxoInput := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: uint16(i)}
+				outputSet[utxoInput.ID()] = &iotago.SigLockedSingleOutput{Address: addr, Amount: amount}
+			}
+			sum, err := outputSet.SumDeposit()
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.sum, sum)
+		})
+	}
+}
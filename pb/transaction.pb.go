@@ -0,0 +1,1150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.27.1
+// 	protoc        (unknown)
+// source: proto/transaction.proto
+
+package pb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Transaction struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Essence      *TransactionEssence `protobuf:"bytes,1,opt,name=essence,proto3" json:"essence,omitempty"`
+	UnlockBlocks []*UnlockBlock      `protobuf:"bytes,2,rep,name=unlockBlocks,proto3" json:"unlockBlocks,omitempty"`
+}
+
+func (x *Transaction) Reset() {
+	*x = Transaction{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Transaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Transaction) ProtoMessage() {}
+
+func (x *Transaction) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Transaction.ProtoReflect.Descriptor instead.
+func (*Transaction) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Transaction) GetEssence() *TransactionEssence {
+	if x != nil {
+		return x.Essence
+	}
+	return nil
+}
+
+func (x *Transaction) GetUnlockBlocks() []*UnlockBlock {
+	if x != nil {
+		return x.UnlockBlocks
+	}
+	return nil
+}
+
+type TransactionEssence struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inputs  []*UTXOInput `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs []*Output    `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Payload *Indexation  `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+}
+
+func (x *TransactionEssence) Reset() {
+	*x = TransactionEssence{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TransactionEssence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionEssence) ProtoMessage() {}
+
+func (x *TransactionEssence) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionEssence.ProtoReflect.Descriptor instead.
+func (*TransactionEssence) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TransactionEssence) GetInputs() []*UTXOInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *TransactionEssence) GetOutputs() []*Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *TransactionEssence) GetPayload() *Indexation {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+type UTXOInput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	TransactionID          []byte `protobuf:"bytes,1,opt,name=transactionID,proto3" json:"transactionID,omitempty"`
+	TransactionOutputIndex uint32 `protobuf:"varint,2,opt,name=transactionOutputIndex,proto3" json:"transactionOutputIndex,omitempty"`
+}
+
+func (x *UTXOInput) Reset() {
+	*x = UTXOInput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UTXOInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UTXOInput) ProtoMessage() {}
+
+func (x *UTXOInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UTXOInput.ProtoReflect.Descriptor instead.
+func (*UTXOInput) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *UTXOInput) GetTransactionID() []byte {
+	if x != nil {
+		return x.TransactionID
+	}
+	return nil
+}
+
+func (x *UTXOInput) GetTransactionOutputIndex() uint32 {
+	if x != nil {
+		return x.TransactionOutputIndex
+	}
+	return 0
+}
+
+type Output struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Output:
+	//	*Output_SigLockedSingleOutput
+	//	*Output_SigLockedDustAllowanceOutput
+	Output isOutput_Output `protobuf_oneof:"output"`
+}
+
+func (x *Output) Reset() {
+	*x = Output{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Output) ProtoMessage() {}
+
+func (x *Output) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Output.ProtoReflect.Descriptor instead.
+func (*Output) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *Output) GetOutput() isOutput_Output {
+	if m != nil {
+		return m.Output
+	}
+	return nil
+}
+
+func (x *Output) GetSigLockedSingleOutput() *SigLockedSingleOutput {
+	if x, ok := x.GetOutput().(*Output_SigLockedSingleOutput); ok {
+		return x.SigLockedSingleOutput
+	}
+	return nil
+}
+
+func (x *Output) GetSigLockedDustAllowanceOutput() *SigLockedDustAllowanceOutput {
+	if x, ok := x.GetOutput().(*Output_SigLockedDustAllowanceOutput); ok {
+		return x.SigLockedDustAllowanceOutput
+	}
+	return nil
+}
+
+type isOutput_Output interface {
+	isOutput_Output()
+}
+
+type Output_SigLockedSingleOutput struct {
+	SigLockedSingleOutput *SigLockedSingleOutput `protobuf:"bytes,1,opt,name=sigLockedSingleOutput,proto3,oneof"`
+}
+
+type Output_SigLockedDustAllowanceOutput struct {
+	SigLockedDustAllowanceOutput *SigLockedDustAllowanceOutput `protobuf:"bytes,2,opt,name=sigLockedDustAllowanceOutput,proto3,oneof"`
+}
+
+func (*Output_SigLockedSingleOutput) isOutput_Output() {}
+
+func (*Output_SigLockedDustAllowanceOutput) isOutput_Output() {}
+
+type SigLockedSingleOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *SigLockedSingleOutput) Reset() {
+	*x = SigLockedSingleOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SigLockedSingleOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigLockedSingleOutput) ProtoMessage() {}
+
+func (x *SigLockedSingleOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigLockedSingleOutput.ProtoReflect.Descriptor instead.
+func (*SigLockedSingleOutput) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *SigLockedSingleOutput) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SigLockedSingleOutput) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type SigLockedDustAllowanceOutput struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address *Address `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Amount  uint64   `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+}
+
+func (x *SigLockedDustAllowanceOutput) Reset() {
+	*x = SigLockedDustAllowanceOutput{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SigLockedDustAllowanceOutput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SigLockedDustAllowanceOutput) ProtoMessage() {}
+
+func (x *SigLockedDustAllowanceOutput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SigLockedDustAllowanceOutput.ProtoReflect.Descriptor instead.
+func (*SigLockedDustAllowanceOutput) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *SigLockedDustAllowanceOutput) GetAddress() *Address {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+func (x *SigLockedDustAllowanceOutput) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Address:
+	//	*Address_Ed25519Address
+	Address isAddress_Address `protobuf_oneof:"address"`
+}
+
+func (x *Address) Reset() {
+	*x = Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Address) ProtoMessage() {}
+
+func (x *Address) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Address.ProtoReflect.Descriptor instead.
+func (*Address) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *Address) GetAddress() isAddress_Address {
+	if m != nil {
+		return m.Address
+	}
+	return nil
+}
+
+func (x *Address) GetEd25519Address() *Ed25519Address {
+	if x, ok := x.GetAddress().(*Address_Ed25519Address); ok {
+		return x.Ed25519Address
+	}
+	return nil
+}
+
+type isAddress_Address interface {
+	isAddress_Address()
+}
+
+type Address_Ed25519Address struct {
+	Ed25519Address *Ed25519Address `protobuf:"bytes,1,opt,name=ed25519Address,proto3,oneof"`
+}
+
+func (*Address_Ed25519Address) isAddress_Address() {}
+
+type Ed25519Address struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Address []byte `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+}
+
+func (x *Ed25519Address) Reset() {
+	*x = Ed25519Address{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ed25519Address) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ed25519Address) ProtoMessage() {}
+
+func (x *Ed25519Address) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ed25519Address.ProtoReflect.Descriptor instead.
+func (*Ed25519Address) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Ed25519Address) GetAddress() []byte {
+	if x != nil {
+		return x.Address
+	}
+	return nil
+}
+
+type Indexation struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Index []byte `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`
+	Data  []byte `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+}
+
+func (x *Indexation) Reset() {
+	*x = Indexation{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Indexation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Indexation) ProtoMessage() {}
+
+func (x *Indexation) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Indexation.ProtoReflect.Descriptor instead.
+func (*Indexation) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *Indexation) GetIndex() []byte {
+	if x != nil {
+		return x.Index
+	}
+	return nil
+}
+
+func (x *Indexation) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UnlockBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to UnlockBlock:
+	//	*UnlockBlock_SignatureUnlockBlock
+	//	*UnlockBlock_ReferenceUnlockBlock
+	UnlockBlock isUnlockBlock_UnlockBlock `protobuf_oneof:"unlockBlock"`
+}
+
+func (x *UnlockBlock) Reset() {
+	*x = UnlockBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UnlockBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockBlock) ProtoMessage() {}
+
+func (x *UnlockBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockBlock.ProtoReflect.Descriptor instead.
+func (*UnlockBlock) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{9}
+}
+
+func (m *UnlockBlock) GetUnlockBlock() isUnlockBlock_UnlockBlock {
+	if m != nil {
+		return m.UnlockBlock
+	}
+	return nil
+}
+
+func (x *UnlockBlock) GetSignatureUnlockBlock() *SignatureUnlockBlock {
+	if x, ok := x.GetUnlockBlock().(*UnlockBlock_SignatureUnlockBlock); ok {
+		return x.SignatureUnlockBlock
+	}
+	return nil
+}
+
+func (x *UnlockBlock) GetReferenceUnlockBlock() *ReferenceUnlockBlock {
+	if x, ok := x.GetUnlockBlock().(*UnlockBlock_ReferenceUnlockBlock); ok {
+		return x.ReferenceUnlockBlock
+	}
+	return nil
+}
+
+type isUnlockBlock_UnlockBlock interface {
+	isUnlockBlock_UnlockBlock()
+}
+
+type UnlockBlock_SignatureUnlockBlock struct {
+	SignatureUnlockBlock *SignatureUnlockBlock `protobuf:"bytes,1,opt,name=signatureUnlockBlock,proto3,oneof"`
+}
+
+type UnlockBlock_ReferenceUnlockBlock struct {
+	ReferenceUnlockBlock *ReferenceUnlockBlock `protobuf:"bytes,2,opt,name=referenceUnlockBlock,proto3,oneof"`
+}
+
+func (*UnlockBlock_SignatureUnlockBlock) isUnlockBlock_UnlockBlock() {}
+
+func (*UnlockBlock_ReferenceUnlockBlock) isUnlockBlock_UnlockBlock() {}
+
+type SignatureUnlockBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Signature:
+	//	*SignatureUnlockBlock_Ed25519Signature
+	Signature isSignatureUnlockBlock_Signature `protobuf_oneof:"signature"`
+}
+
+func (x *SignatureUnlockBlock) Reset() {
+	*x = SignatureUnlockBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SignatureUnlockBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SignatureUnlockBlock) ProtoMessage() {}
+
+func (x *SignatureUnlockBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SignatureUnlockBlock.ProtoReflect.Descriptor instead.
+func (*SignatureUnlockBlock) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{10}
+}
+
+func (m *SignatureUnlockBlock) GetSignature() isSignatureUnlockBlock_Signature {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (x *SignatureUnlockBlock) GetEd25519Signature() *Ed25519Signature {
+	if x, ok := x.GetSignature().(*SignatureUnlockBlock_Ed25519Signature); ok {
+		return x.Ed25519Signature
+	}
+	return nil
+}
+
+type isSignatureUnlockBlock_Signature interface {
+	isSignatureUnlockBlock_Signature()
+}
+
+type SignatureUnlockBlock_Ed25519Signature struct {
+	Ed25519Signature *Ed25519Signature `protobuf:"bytes,1,opt,name=ed25519Signature,proto3,oneof"`
+}
+
+func (*SignatureUnlockBlock_Ed25519Signature) isSignatureUnlockBlock_Signature() {}
+
+type Ed25519Signature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	PublicKey []byte `protobuf:"bytes,1,opt,name=publicKey,proto3" json:"publicKey,omitempty"`
+	Signature []byte `protobuf:"bytes,2,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *Ed25519Signature) Reset() {
+	*x = Ed25519Signature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Ed25519Signature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ed25519Signature) ProtoMessage() {}
+
+func (x *Ed25519Signature) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ed25519Signature.ProtoReflect.Descriptor instead.
+func (*Ed25519Signature) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Ed25519Signature) GetPublicKey() []byte {
+	if x != nil {
+		return x.PublicKey
+	}
+	return nil
+}
+
+func (x *Ed25519Signature) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type ReferenceUnlockBlock struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Reference uint32 `protobuf:"varint,1,opt,name=reference,proto3" json:"reference,omitempty"`
+}
+
+func (x *ReferenceUnlockBlock) Reset() {
+	*x = ReferenceUnlockBlock{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_transaction_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReferenceUnlockBlock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReferenceUnlockBlock) ProtoMessage() {}
+
+func (x *ReferenceUnlockBlock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_transaction_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReferenceUnlockBlock.ProtoReflect.Descriptor instead.
+func (*ReferenceUnlockBlock) Descriptor() ([]byte, []int) {
+	return file_proto_transaction_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ReferenceUnlockBlock) GetReference() uint32 {
+	if x != nil {
+		return x.Reference
+	}
+	return 0
+}
+
+var File_proto_transaction_proto protoreflect.FileDescriptor
+
+var file_proto_transaction_proto_rawDesc = []byte{
+	0x0a, 0x17, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x74, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x86, 0x01, 0x0a, 0x0b, 0x54, 0x72, 0x61, 0x6e, 0x73,
+	0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x39, 0x0a, 0x07, 0x65, 0x73, 0x73, 0x65, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f,
+	0x6e, 0x45, 0x73, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x52, 0x07, 0x65, 0x73, 0x73, 0x65, 0x6e, 0x63,
+	0x65, 0x12, 0x3c, 0x0a, 0x0c, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x52, 0x0c, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x73, 0x22,
+	0xa6, 0x01, 0x0a, 0x12, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x45,
+	0x73, 0x73, 0x65, 0x6e, 0x63, 0x65, 0x12, 0x2e, 0x0a, 0x06, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73,
+	0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x55, 0x54, 0x58, 0x4f, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x52, 0x06,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x12, 0x2d, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x52, 0x07, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x31, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x69, 0x0a, 0x09, 0x55, 0x54, 0x58, 0x4f,
+	0x49, 0x6e, 0x70, 0x75, 0x74, 0x12, 0x24, 0x0a, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x0d, 0x74, 0x72,
+	0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x49, 0x44, 0x12, 0x36, 0x0a, 0x16, 0x74,
+	0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x49, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x16, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x22, 0xdf, 0x01, 0x0a, 0x06, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x5a,
+	0x0a, 0x15, 0x73, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x67, 0x6c,
+	0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x4c,
+	0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75,
+	0x74, 0x48, 0x00, 0x52, 0x15, 0x73, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x69,
+	0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x6f, 0x0a, 0x1c, 0x73, 0x69,
+	0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x75, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77,
+	0x61, 0x6e, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53,
+	0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x75, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f,
+	0x77, 0x61, 0x6e, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x48, 0x00, 0x52, 0x1c, 0x73,
+	0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b, 0x65, 0x64, 0x44, 0x75, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f,
+	0x77, 0x61, 0x6e, 0x63, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x42, 0x08, 0x0a, 0x06, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x22, 0x5f, 0x0a, 0x15, 0x53, 0x69, 0x67, 0x4c, 0x6f, 0x63, 0x6b,
+	0x65, 0x64, 0x53, 0x69, 0x6e, 0x67, 0x6c, 0x65, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x2e,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x14, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16,
+	0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x66, 0x0a, 0x1c, 0x53, 0x69, 0x67, 0x4c, 0x6f, 0x63,
+	0x6b, 0x65, 0x64, 0x44, 0x75, 0x73, 0x74, 0x41, 0x6c, 0x6c, 0x6f, 0x77, 0x61, 0x6e, 0x63, 0x65,
+	0x4f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0x5b,
+	0x0a, 0x07, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x45, 0x0a, 0x0e, 0x65, 0x64, 0x32,
+	0x35, 0x35, 0x31, 0x39, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1b, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e,
+	0x45, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x48, 0x00,
+	0x52, 0x0e, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x42, 0x09, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x2a, 0x0a, 0x0e, 0x45,
+	0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0x36, 0x0a, 0x0a, 0x49, 0x6e, 0x64, 0x65, 0x78,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x14, 0x0a, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0c, 0x52, 0x05, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x12, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22,
+	0xce, 0x01, 0x0a, 0x0b, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12,
+	0x57, 0x0a, 0x14, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f,
+	0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e,
+	0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x48, 0x00, 0x52, 0x14, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x57, 0x0a, 0x14, 0x72, 0x65, 0x66, 0x65,
+	0x72, 0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63,
+	0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e,
+	0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x48, 0x00, 0x52, 0x14, 0x72, 0x65, 0x66,
+	0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x55, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x42, 0x0d, 0x0a, 0x0b, 0x75, 0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x22, 0x70, 0x0a, 0x14, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x55, 0x6e, 0x6c,
+	0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x4b, 0x0a, 0x10, 0x65, 0x64, 0x32, 0x35,
+	0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x1d, 0x2e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x2e, 0x45, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x48, 0x00, 0x52, 0x10, 0x65, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x42, 0x0b, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x4e, 0x0a, 0x10, 0x45, 0x64, 0x32, 0x35, 0x35, 0x31, 0x39, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69, 0x63,
+	0x4b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x70, 0x75, 0x62, 0x6c, 0x69,
+	0x63, 0x4b, 0x65, 0x79, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x09, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x22, 0x34, 0x0a, 0x14, 0x52, 0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x55,
+	0x6e, 0x6c, 0x6f, 0x63, 0x6b, 0x42, 0x6c, 0x6f, 0x63, 0x6b, 0x12, 0x1c, 0x0a, 0x09, 0x72, 0x65,
+	0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x09, 0x72,
+	0x65, 0x66, 0x65, 0x72, 0x65, 0x6e, 0x63, 0x65, 0x42, 0x28, 0x5a, 0x26, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x69, 0x6f, 0x74, 0x61, 0x6c, 0x65, 0x64, 0x67, 0x65,
+	0x72, 0x2f, 0x69, 0x6f, 0x74, 0x61, 0x2e, 0x67, 0x6f, 0x2f, 0x76, 0x32, 0x2f, 0x70, 0x62, 0x3b,
+	0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_transaction_proto_rawDescOnce sync.Once
+	file_proto_transaction_proto_rawDescData = file_proto_transaction_proto_rawDesc
+)
+
+func file_proto_transaction_proto_rawDescGZIP() []byte {
+	file_proto_transaction_proto_rawDescOnce.Do(func() {
+		file_proto_transaction_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_transaction_proto_rawDescData)
+	})
+	return file_proto_transaction_proto_rawDescData
+}
+
+var file_proto_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 13)
+var file_proto_transaction_proto_goTypes = []interface{}{
+	(*Transaction)(nil),                  // 0: transaction.Transaction
+	(*TransactionEssence)(nil),           // 1: transaction.TransactionEssence
+	(*UTXOInput)(nil),                    // 2: transaction.UTXOInput
+	(*Output)(nil),                       // 3: transaction.Output
+	(*SigLockedSingleOutput)(nil),        // 4: transaction.SigLockedSingleOutput
+	(*SigLockedDustAllowanceOutput)(nil), // 5: transaction.SigLockedDustAllowanceOutput
+	(*Address)(nil),                      // 6: transaction.Address
+	(*Ed25519Address)(nil),               // 7: transaction.Ed25519Address
+	(*Indexation)(nil),                   // 8: transaction.Indexation
+	(*UnlockBlock)(nil),                  // 9: transaction.UnlockBlock
+	(*SignatureUnlockBlock)(nil),         // 10: transaction.SignatureUnlockBlock
+	(*Ed25519Signature)(nil),             // 11: transaction.Ed25519Signature
+	(*ReferenceUnlockBlock)(nil),         // 12: transaction.ReferenceUnlockBlock
+}
+var file_proto_transaction_proto_depIdxs = []int32{
+	1,  // 0: transaction.Transaction.essence:type_name -> transaction.TransactionEssence
+	9,  // 1: transaction.Transaction.unlockBlocks:type_name -> transaction.UnlockBlock
+	2,  // 2: transaction.TransactionEssence.inputs:type_name -> transaction.UTXOInput
+	3,  // 3: transaction.TransactionEssence.outputs:type_name -> transaction.Output
+	8,  // 4: transaction.TransactionEssence.payload:type_name -> transaction.Indexation
+	4,  // 5: transaction.Output.sigLockedSingleOutput:type_name -> transaction.SigLockedSingleOutput
+	5,  // 6: transaction.Output.sigLockedDustAllowanceOutput:type_name -> transaction.SigLockedDustAllowanceOutput
+	6,  // 7: transaction.SigLockedSingleOutput.address:type_name -> transaction.Address
+	6,  // 8: transaction.SigLockedDustAllowanceOutput.address:type_name -> transaction.Address
+	7,  // 9: transaction.Address.ed25519Address:type_name -> transaction.Ed25519Address
+	10, // 10: transaction.UnlockBlock.signatureUnlockBlock:type_name -> transaction.SignatureUnlockBlock
+	12, // 11: transaction.UnlockBlock.referenceUnlockBlock:type_name -> transaction.ReferenceUnlockBlock
+	11, // 12: transaction.SignatureUnlockBlock.ed25519Signature:type_name -> transaction.Ed25519Signature
+	13, // [13:13] is the sub-list for method output_type
+	13, // [13:13] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_proto_transaction_proto_init() }
+func file_proto_transaction_proto_init() {
+	if File_proto_transaction_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_transaction_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Transaction); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TransactionEssence); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UTXOInput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Output); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SigLockedSingleOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SigLockedDustAllowanceOutput); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ed25519Address); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Indexation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UnlockBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SignatureUnlockBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Ed25519Signature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_transaction_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReferenceUnlockBlock); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_transaction_proto_msgTypes[3].OneofWrappers = []interface{}{
+		(*Output_SigLockedSingleOutput)(nil),
+		(*Output_SigLockedDustAllowanceOutput)(nil),
+	}
+	file_proto_transaction_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*Address_Ed25519Address)(nil),
+	}
+	file_proto_transaction_proto_msgTypes[9].OneofWrappers = []interface{}{
+		(*UnlockBlock_SignatureUnlockBlock)(nil),
+		(*UnlockBlock_ReferenceUnlockBlock)(nil),
+	}
+	file_proto_transaction_proto_msgTypes[10].OneofWrappers = []interface{}{
+		(*SignatureUnlockBlock_Ed25519Signature)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_transaction_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   13,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_transaction_proto_goTypes,
+		DependencyIndexes: file_proto_transaction_proto_depIdxs,
+		MessageInfos:      file_proto_transaction_proto_msgTypes,
+	}.Build()
+	File_proto_transaction_proto = out.File
+	file_proto_transaction_proto_rawDesc = nil
+	file_proto_transaction_proto_goTypes = nil
+	file_proto_transaction_proto_depIdxs = nil
+}
@@ -37,8 +37,13 @@ func (s *SigLockedDustAllowanceOutput) Deposit() (uint64, error) {
 	return s.Amount, nil
 }
 
+// Size returns the size of the SigLockedDustAllowanceOutput in its serialized form.
+func (s *SigLockedDustAllowanceOutput) Size() int {
+	return serializer.SmallTypeDenotationByteSize + mustSerializedSize(s.Address) + serializer.UInt64ByteSize
+}
+
 func (s *SigLockedDustAllowanceOutput) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(SigLockedDustAllowanceOutputBytesMinSize, len(data)); err != nil {
@@ -68,6 +73,7 @@ func (s *SigLockedDustAllowanceOutput) Deserialize(data []byte, deSeriMode seria
 			return nil
 		}).
 		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (s *SigLockedDustAllowanceOutput) Serialize(deSeriMode serializer.DeSerializationMode) (data []byte, err error) {
@@ -97,6 +103,31 @@ func (s *SigLockedDustAllowanceOutput) Serialize(deSeriMode serializer.DeSeriali
 		}).Serialize()
 }
 
+// appends the serialized form of the SigLockedDustAllowanceOutput to dst, which equals the output of Serialize().
+func (s *SigLockedDustAllowanceOutput) appendSerialized(dst []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+		if err := outputAmountValidator(-1, s); err != nil {
+			return dst, fmt.Errorf("%w: unable to serialize signature locked dust allowance output", err)
+		}
+	}
+	start := len(dst)
+	dst = append(dst, OutputSigLockedDustAllowanceOutput)
+	switch addr := s.Address.(type) {
+	case *Ed25519Address:
+		dst = addr.appendSerialized(dst)
+	default:
+		if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+			return dst[:start], fmt.Errorf("%w: signature locked dust allowance output defines unknown address", ErrUnknownAddrType)
+		}
+		addrData, err := s.Address.Serialize(deSeriMode)
+		if err != nil {
+			return dst[:start], fmt.Errorf("unable to serialize signature locked dust allowance output address: %w", err)
+		}
+		dst = append(dst, addrData...)
+	}
+	return appendUint64(dst, s.Amount), nil
+}
+
 func (s *SigLockedDustAllowanceOutput) MarshalJSON() ([]byte, error) {
 	jSigLockedDustAllowanceOutput := &jsonSigLockedDustAllowanceOutput{}
 
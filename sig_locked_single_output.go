@@ -36,8 +36,13 @@ func (s *SigLockedSingleOutput) Deposit() (uint64, error) {
 	return s.Amount, nil
 }
 
+// Size returns the size of the SigLockedSingleOutput in its serialized form.
+func (s *SigLockedSingleOutput) Size() int {
+	return serializer.SmallTypeDenotationByteSize + mustSerializedSize(s.Address) + serializer.UInt64ByteSize
+}
+
 func (s *SigLockedSingleOutput) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(SigLockedSingleOutputBytesMinSize, len(data)); err != nil {
@@ -67,6 +72,7 @@ func (s *SigLockedSingleOutput) Deserialize(data []byte, deSeriMode serializer.D
 			return nil
 		}).
 		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (s *SigLockedSingleOutput) Serialize(deSeriMode serializer.DeSerializationMode) (data []byte, err error) {
@@ -96,6 +102,31 @@ func (s *SigLockedSingleOutput) Serialize(deSeriMode serializer.DeSerializationM
 		}).Serialize()
 }
 
+// appends the serialized form of the SigLockedSingleOutput to dst, which equals the output of Serialize().
+func (s *SigLockedSingleOutput) appendSerialized(dst []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+		if err := outputAmountValidator(-1, s); err != nil {
+			return dst, fmt.Errorf("%w: unable to serialize signature locked single output", err)
+		}
+	}
+	start := len(dst)
+	dst = append(dst, OutputSigLockedSingleOutput)
+	switch addr := s.Address.(type) {
+	case *Ed25519Address:
+		dst = addr.appendSerialized(dst)
+	default:
+		if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+			return dst[:start], fmt.Errorf("%w: signature locked single output defines unknown address", ErrUnknownAddrType)
+		}
+		addrData, err := s.Address.Serialize(deSeriMode)
+		if err != nil {
+			return dst[:start], fmt.Errorf("unable to serialize signature locked single output address: %w", err)
+		}
+		dst = append(dst, addrData...)
+	}
+	return appendUint64(dst, s.Amount), nil
+}
+
 func (s *SigLockedSingleOutput) MarshalJSON() ([]byte, error) {
 	jSigLockedSingleOutput := &jsonSigLockedSingleOutput{}
 
@@ -30,6 +30,12 @@ var (
 	ErrEd25519SignatureInvalid = errors.New("signature is invalid (Ed25519")
 )
 
+// the order of the Ed25519 base point in little-endian, which the S part of a canonical signature must be less than.
+var ed25519GroupOrder = [32]byte{
+	0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+	0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+}
+
 // SignatureSelector implements SerializableSelectorFunc for signature types.
 func SignatureSelector(sigType uint32) (serializer.Serializable, error) {
 	var seri serializer.Serializable
@@ -63,13 +69,35 @@ func (e *Ed25519Signature) Valid(msg []byte, addr *Ed25519Address) error {
 	return nil
 }
 
+// Canonical tells whether the S part of the signature is encoded canonically, that is, is less than the group order.
+// Non-canonical signatures are rejected by Valid() anyway, but Canonical() allows to do so without verifying the signature.
+func (e *Ed25519Signature) Canonical() bool {
+	s := e.Signature[ed25519.SignatureSize/2:]
+	for i := len(ed25519GroupOrder) - 1; i >= 0; i-- {
+		switch {
+		case s[i] < ed25519GroupOrder[i]:
+			return true
+		case s[i] > ed25519GroupOrder[i]:
+			return false
+		}
+	}
+	// equal to the group order
+	return false
+}
+
+// Size returns the size of the Ed25519Signature in its serialized form.
+func (e *Ed25519Signature) Size() int {
+	return Ed25519SignatureSerializedBytesSize
+}
+
 func (e *Ed25519Signature) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
+	// the length is checked regardless of the mode as the fixed size fields are sliced out of data
+	if err := serializer.CheckMinByteLength(Ed25519SignatureSerializedBytesSize, len(data)); err != nil {
+		return 0, deserializationError(0, fmt.Errorf("invalid Ed25519 signature bytes: %w", err))
+	}
 	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
-		if err := serializer.CheckMinByteLength(Ed25519SignatureSerializedBytesSize, len(data)); err != nil {
-			return 0, fmt.Errorf("invalid Ed25519 signature bytes: %w", err)
-		}
 		if err := serializer.CheckTypeByte(data, SignatureEd25519); err != nil {
-			return 0, fmt.Errorf("unable to deserialize Ed25519 signature: %w", err)
+			return 0, deserializationError(0, fmt.Errorf("unable to deserialize Ed25519 signature: %w", err))
 		}
 	}
 	// skip type byte
@@ -140,6 +168,13 @@ func (j *jsonEd25519Signature) ToSerializable() (serializer.Serializable, error)
 		return nil, fmt.Errorf("unable to decode signature from JSON for Ed25519 signature: %w", err)
 	}
 
+	if len(pubKeyBytes) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("%w: Ed25519 public key must be %d bytes long but is %d", ErrSignatureAndAddrIncompatible, ed25519.PublicKeySize, len(pubKeyBytes))
+	}
+	if len(sigBytes) != ed25519.SignatureSize {
+		return nil, fmt.Errorf("%w: Ed25519 signature must be %d bytes long but is %d", ErrSignatureAndAddrIncompatible, ed25519.SignatureSize, len(sigBytes))
+	}
+
 	copy(sig.PublicKey[:], pubKeyBytes)
 	copy(sig.Signature[:], sigBytes)
 	return sig, nil
@@ -2,11 +2,13 @@ package iotago_test
 
 import (
 	"errors"
+	"fmt"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -68,3 +70,52 @@ func TestEd25519Signature_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestEd25519Signature_Canonical(t *testing.T) {
+	// the order of the Ed25519 base point in little-endian
+	groupOrder := [32]byte{
+		0xed, 0xd3, 0xf5, 0x5c, 0x1a, 0x63, 0x12, 0x58, 0xd6, 0x9c, 0xf7, 0xa2, 0xde, 0xf9, 0xde, 0x14,
+		0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x10,
+	}
+
+	prvKey := tpkg.RandEd25519PrivateKey()
+	sig := &iotago.Ed25519Signature{}
+	copy(sig.PublicKey[:], prvKey.Public().(ed25519.PublicKey))
+	copy(sig.Signature[:], ed25519.Sign(prvKey, []byte("message")))
+	assert.True(t, sig.Canonical())
+
+	copy(sig.Signature[32:], groupOrder[:])
+	assert.False(t, sig.Canonical())
+
+	sig.Signature[32]--
+	assert.True(t, sig.Canonical())
+
+	sig.Signature[63] = 0xff
+	assert.False(t, sig.Canonical())
+}
+
+func TestEd25519Signature_UnmarshalJSONLength(t *testing.T) {
+	tests := []struct {
+		name      string
+		pubKeyLen int
+		sigLen    int
+		err       error
+	}{
+		{name: "ok", pubKeyLen: ed25519.PublicKeySize, sigLen: ed25519.SignatureSize},
+		{name: "err - truncated signature", pubKeyLen: ed25519.PublicKeySize, sigLen: ed25519.SignatureSize - 1, err: iotago.ErrSignatureAndAddrIncompatible},
+		{name: "err - overlong signature", pubKeyLen: ed25519.PublicKeySize, sigLen: ed25519.SignatureSize + 1, err: iotago.ErrSignatureAndAddrIncompatible},
+		{name: "err - truncated public key", pubKeyLen: ed25519.PublicKeySize - 1, sigLen: ed25519.SignatureSize, err: iotago.ErrSignatureAndAddrIncompatible},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sigJSON := fmt.Sprintf(`{"type":0,"publicKey":"%x","signature":"%x"}`, tpkg.RandBytes(tt.pubKeyLen), tpkg.RandBytes(tt.sigLen))
+			err := (&iotago.Ed25519Signature{}).UnmarshalJSON([]byte(sigJSON))
+			if tt.err != nil {
+				assert.True(t, errors.Is(err, tt.err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
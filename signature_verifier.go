@@ -0,0 +1,178 @@
+package iotago
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+// SignatureVerifier verifies the signatures of signature unlock blocks against the addresses they unlock.
+// Implementations may verify a signature directly within Verify or defer its verification until Flush is called.
+type SignatureVerifier interface {
+	// Verify verifies, or queues the verification of, the given signature of msg against addr.
+	Verify(msg []byte, sig serializer.Serializable, addr Address) error
+	// Flush verifies all signatures of which the verification was deferred by Verify.
+	Flush() error
+}
+
+// DefaultSignatureVerifier is the SignatureVerifier used by Transaction.SemanticallyValidate().
+// It verifies every signature directly within Verify.
+var DefaultSignatureVerifier SignatureVerifier = defaultSignatureVerifier{}
+
+type defaultSignatureVerifier struct{}
+
+func (defaultSignatureVerifier) Verify(msg []byte, sig serializer.Serializable, addr Address) error {
+	return verifySignature(msg, sig, addr)
+}
+
+func (defaultSignatureVerifier) Flush() error {
+	return nil
+}
+
+// verifies the given signature of msg against addr.
+func verifySignature(msg []byte, sig serializer.Serializable, addr Address) error {
+	switch addr := addr.(type) {
+	case *Ed25519Address:
+		ed25519Sig, isEd25519Sig := sig.(*Ed25519Signature)
+		if !isEd25519Sig {
+			return fmt.Errorf("%w: Ed25519 address but signature of type %T", ErrSignatureAndAddrIncompatible, sig)
+		}
+		return ed25519Sig.Valid(msg, addr)
+	default:
+		return fmt.Errorf("%w: type %T", ErrUnknownAddrType, addr)
+	}
+}
+
+// implemented by the SignatureVerifier(s) of this package which defer the verification of signatures,
+// so that the errors returned by their Flush() carry the index of the input a signature unlocks,
+// just like the errors of signatures verified directly within Transaction.SemanticallyValidate().
+type inputSignatureVerifier interface {
+	// verifyInput is like Verify but additionally receives the index of the input the signature unlocks
+	// and the index of the signature unlock block holding it.
+	verifyInput(inputIndex int, sigBlockIndex int, msg []byte, sig serializer.Serializable, addr Address) error
+}
+
+// BatchSignatureVerifier is a SignatureVerifier which accumulates the signatures passed to Verify
+// and verifies all of them at once when Flush is called. A BatchSignatureVerifier can be shared
+// among the semantic validation of multiple transactions, in which case none of those transactions
+// must be considered valid before Flush returned without an error.
+// A BatchSignatureVerifier is not safe for concurrent use.
+type BatchSignatureVerifier struct {
+	batch []batchedSignature
+}
+
+// a signature queued for verification by a BatchSignatureVerifier.
+type batchedSignature struct {
+	msg  []byte
+	sig  serializer.Serializable
+	addr Address
+	// the index of the input the signature unlocks and of its signature unlock block, -1 if unknown.
+	inputIndex    int
+	sigBlockIndex int
+}
+
+// verifies the batched signature. If the input the signature unlocks is known, the returned error equals
+// the one of a signature verified directly by the semantic validation, otherwise it refers to the batch index.
+func (b *batchedSignature) verify(batchIndex int) error {
+	err := verifySignature(b.msg, b.sig, b.addr)
+	switch {
+	case err == nil:
+		return nil
+	case b.inputIndex == -1:
+		return fmt.Errorf("%w: signature at index %d of the batch", err, batchIndex)
+	default:
+		return signatureVerificationError(b.inputIndex, b.sigBlockIndex, err)
+	}
+}
+
+// NewBatchSignatureVerifier creates a new BatchSignatureVerifier.
+func NewBatchSignatureVerifier() *BatchSignatureVerifier {
+	return &BatchSignatureVerifier{}
+}
+
+// Verify queues the verification of the given signature of msg against addr.
+func (v *BatchSignatureVerifier) Verify(msg []byte, sig serializer.Serializable, addr Address) error {
+	return v.verifyInput(-1, -1, msg, sig, addr)
+}
+
+func (v *BatchSignatureVerifier) verifyInput(inputIndex int, sigBlockIndex int, msg []byte, sig serializer.Serializable, addr Address) error {
+	v.batch = append(v.batch, batchedSignature{msg: msg, sig: sig, addr: addr, inputIndex: inputIndex, sigBlockIndex: sigBlockIndex})
+	return nil
+}
+
+// Flush verifies all queued signatures and empties the batch.
+// The returned error refers to the first invalid signature within the batch. If the signature was queued
+// by the semantic validation of a Transaction, the error is a *SemanticError carrying the index of the input it unlocks.
+func (v *BatchSignatureVerifier) Flush() error {
+	batch := v.batch
+	v.batch = nil
+	for i := range batch {
+		if err := batch[i].verify(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ParallelSignatureVerifier is a SignatureVerifier which, like BatchSignatureVerifier, accumulates the signatures
+// passed to Verify but verifies them concurrently through a pool of workers when Flush is called.
+// Which signature is reported as invalid does not depend on the amount of workers or the scheduling of them:
+// Flush always reports the first invalid signature within the batch, just like BatchSignatureVerifier does.
+// A ParallelSignatureVerifier is not safe for concurrent use.
+type ParallelSignatureVerifier struct {
+	workers int
+	batch   []batchedSignature
+}
+
+// NewParallelSignatureVerifier creates a new ParallelSignatureVerifier which verifies signatures using the given amount of workers.
+// A worker count below 1 is treated as 1.
+func NewParallelSignatureVerifier(workers int) *ParallelSignatureVerifier {
+	if workers < 1 {
+		workers = 1
+	}
+	return &ParallelSignatureVerifier{workers: workers}
+}
+
+// Verify queues the verification of the given signature of msg against addr.
+func (v *ParallelSignatureVerifier) Verify(msg []byte, sig serializer.Serializable, addr Address) error {
+	return v.verifyInput(-1, -1, msg, sig, addr)
+}
+
+func (v *ParallelSignatureVerifier) verifyInput(inputIndex int, sigBlockIndex int, msg []byte, sig serializer.Serializable, addr Address) error {
+	v.batch = append(v.batch, batchedSignature{msg: msg, sig: sig, addr: addr, inputIndex: inputIndex, sigBlockIndex: sigBlockIndex})
+	return nil
+}
+
+// Flush concurrently verifies all queued signatures and empties the batch.
+// The returned error refers to the first invalid signature within the batch. If the signature was queued
+// by the semantic validation of a Transaction, the error is a *SemanticError carrying the index of the input it unlocks.
+func (v *ParallelSignatureVerifier) Flush() error {
+	batch := v.batch
+	v.batch = nil
+
+	errs := make([]error, len(batch))
+	indices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < v.workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indices {
+				errs[i] = batch[i].verify(i)
+			}
+		}()
+	}
+	for i := range batch {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,174 @@
+package iotago_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchSignatureVerifier(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	type signedTx struct {
+		tx         *iotago.Transaction
+		inputUTXOs iotago.InputToOutputMapping
+	}
+
+	newSignedTx := func() signedTx {
+		outputAddr1, _ := tpkg.RandEd25519Address()
+		inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+		tx, err := iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+			Build(iotago.NewInMemoryAddressSigner(addrKeys))
+		assert.NoError(t, err)
+		return signedTx{
+			tx: tx,
+			inputUTXOs: iotago.InputToOutputMapping{
+				inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+			},
+		}
+	}
+
+	t.Run("ok - all signatures valid", func(t *testing.T) {
+		verifier := iotago.NewBatchSignatureVerifier()
+		for i := 0; i < 5; i++ {
+			signed := newSignedTx()
+			assert.NoError(t, signed.tx.SemanticallyValidateWithVerifier(signed.inputUTXOs, verifier))
+		}
+		assert.NoError(t, verifier.Flush())
+	})
+
+	t.Run("err - single invalid signature within the batch", func(t *testing.T) {
+		verifier := iotago.NewBatchSignatureVerifier()
+		var serialErr error
+		for i := 0; i < 5; i++ {
+			signed := newSignedTx()
+			if i == 3 {
+				sig := signed.tx.UnlockBlocks[0].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature)
+				sig.Signature[0] ^= 0xff
+				// verified directly, the invalid signature is detected immediately
+				serialErr = signed.tx.SemanticallyValidate(signed.inputUTXOs)
+				assert.True(t, errors.Is(serialErr, iotago.ErrEd25519SignatureInvalid))
+			}
+			// the verification is deferred to Flush
+			assert.NoError(t, signed.tx.SemanticallyValidateWithVerifier(signed.inputUTXOs, verifier))
+		}
+		err := verifier.Flush()
+		assert.True(t, errors.Is(err, iotago.ErrEd25519SignatureInvalid))
+		assert.Equal(t, serialErr.Error(), err.Error())
+		var semErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &semErr))
+		assert.Equal(t, 0, semErr.InputIndex)
+
+		// the batch is emptied by Flush
+		assert.NoError(t, verifier.Flush())
+	})
+}
+
+// verifies signatures through an external module, like an HSM, and records the calls it gets.
+type externalSignatureVerifier struct {
+	verify func(msg []byte, sig serializer.Serializable, addr iotago.Address) error
+	msgs   [][]byte
+	addrs  []iotago.Address
+}
+
+func (v *externalSignatureVerifier) Verify(msg []byte, sig serializer.Serializable, addr iotago.Address) error {
+	v.msgs = append(v.msgs, msg)
+	v.addrs = append(v.addrs, addr)
+	return v.verify(msg, sig, addr)
+}
+
+func (v *externalSignatureVerifier) Flush() error {
+	return nil
+}
+
+func TestExternalSignatureVerifier(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXOs := iotago.InputToOutputMapping{inputUTXO.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50}}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}))
+	assert.NoError(t, err)
+
+	signingMessage, err := tx.Essence.(*iotago.TransactionEssence).SigningMessage()
+	assert.NoError(t, err)
+
+	t.Run("ok - delegates to the verifier", func(t *testing.T) {
+		verifier := &externalSignatureVerifier{verify: iotago.DefaultSignatureVerifier.Verify}
+		assert.NoError(t, tx.SemanticallyValidateWithVerifier(inputUTXOs, verifier))
+		assert.Equal(t, [][]byte{signingMessage}, verifier.msgs)
+		assert.Equal(t, []iotago.Address{&inputAddr}, verifier.addrs)
+	})
+
+	t.Run("err - rejected by the verifier", func(t *testing.T) {
+		errRejected := errors.New("rejected by HSM")
+		verifier := &externalSignatureVerifier{verify: func([]byte, serializer.Serializable, iotago.Address) error {
+			return errRejected
+		}}
+		err := tx.SemanticallyValidateWithVerifier(inputUTXOs, verifier)
+		assert.True(t, errors.Is(err, errRejected))
+		var semErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &semErr))
+		assert.Equal(t, 0, semErr.InputIndex)
+	})
+}
+
+func TestTransaction_SemanticallyValidateParallel(t *testing.T) {
+	const inputCount = 20
+
+	newSignedTx := func() (*iotago.Transaction, iotago.InputToOutputMapping) {
+		builder := iotago.NewTransactionBuilder()
+		var addrKeys []iotago.AddressKeys
+		inputUTXOs := iotago.InputToOutputMapping{}
+		for i := 0; i < inputCount; i++ {
+			identity := tpkg.RandEd25519PrivateKey()
+			inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+			addrKeys = append(addrKeys, iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+			inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+			inputUTXOs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50}
+			builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO})
+		}
+		outputAddr, _ := tpkg.RandEd25519Address()
+		tx, err := builder.
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 50 * inputCount}).
+			Build(iotago.NewInMemoryAddressSigner(addrKeys...))
+		assert.NoError(t, err)
+		return tx, inputUTXOs
+	}
+
+	tx, inputUTXOs := newSignedTx()
+	assert.NoError(t, tx.SemanticallyValidate(inputUTXOs))
+	assert.NoError(t, tx.SemanticallyValidateParallel(inputUTXOs, 4))
+
+	// invalidate some of the signatures: both variants must reject the transaction for the same reason
+	for _, i := range []int{3, 11, 17} {
+		sig := tx.UnlockBlocks[i].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature)
+		sig.Signature[0] ^= 0xff
+	}
+	serialValidationErr := tx.SemanticallyValidate(inputUTXOs)
+	assert.True(t, errors.Is(serialValidationErr, iotago.ErrEd25519SignatureInvalid))
+	var serialErr *iotago.SemanticError
+	assert.True(t, errors.As(serialValidationErr, &serialErr))
+	for _, workers := range []int{0, 1, 4, inputCount * 2} {
+		err := tx.SemanticallyValidateParallel(inputUTXOs, workers)
+		assert.True(t, errors.Is(err, iotago.ErrEd25519SignatureInvalid))
+		assert.Equal(t, serialValidationErr.Error(), err.Error())
+
+		var parallelErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &parallelErr))
+		assert.Equal(t, serialErr.InputIndex, parallelErr.InputIndex)
+		assert.Equal(t, serialErr.OutputIndex, parallelErr.OutputIndex)
+	}
+}
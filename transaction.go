@@ -1,9 +1,15 @@
 package iotago
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/iotaledger/hive.go/serializer"
 
@@ -20,6 +26,10 @@ const (
 	// TransactionBinSerializedMinSize defines the minimum size of a serialized Transaction.
 	TransactionBinSerializedMinSize = serializer.UInt32ByteSize
 
+	// TransactionFormatVersion1 denotes the first version of the envelope produced by Transaction.SerializeVersioned():
+	// the format version byte followed by the Transaction in its serialized form.
+	TransactionFormatVersion1 byte = 1
+
 	// DustAllowanceDivisor defines the divisor used to compute the allowed dust outputs on an address.
 	// The amount of dust outputs on an address is calculated by:
 	//	min(sum(dust_allowance_output_deposit) / DustAllowanceDivisor, dustOutputCountLimit)
@@ -43,6 +53,32 @@ var (
 	ErrSignatureAndAddrIncompatible = errors.New("address and signature type are not compatible")
 	// ErrInvalidDustAllowance gets returned for errors where the dust allowance is semantically invalid.
 	ErrInvalidDustAllowance = errors.New("invalid dust allowance")
+	// ErrOutputRecipientNotWhitelisted gets returned if an output deposits to an address which is not whitelisted.
+	ErrOutputRecipientNotWhitelisted = errors.New("output recipient is not whitelisted")
+	// ErrEmbeddedPayloadTagNotAllowed gets returned if the index of an embedded indexation payload is outside the allowed namespace.
+	ErrEmbeddedPayloadTagNotAllowed = errors.New("embedded indexation payload index is not within the allowed namespace")
+	// ErrEmbeddedPayloadNotAllowed gets returned if a transaction essence embeds a payload while it is forbidden to do so.
+	ErrEmbeddedPayloadNotAllowed = errors.New("embedded payload is not allowed")
+	// ErrFeeBelowMinimum gets returned if a transaction pays less than the required minimum fee to the fee address.
+	ErrFeeBelowMinimum = errors.New("fee paid is below the minimum")
+	// ErrOutputValueConcentrationExceeded gets returned if an output holds a bigger fraction of the total output value than allowed.
+	ErrOutputValueConcentrationExceeded = errors.New("output holds too big a fraction of the total output value")
+	// ErrChangeOutputMissing gets returned if a transaction consumes funds of an address without depositing change back to it.
+	ErrChangeOutputMissing = errors.New("change output is missing")
+	// ErrTotalValueBelowMinimum gets returned if a transaction consumes less than the required minimum total input value.
+	ErrTotalValueBelowMinimum = errors.New("total input value is below the minimum")
+	// ErrInputTooOld gets returned if a transaction consumes an UTXO which was created too many milestones ago.
+	ErrInputTooOld = errors.New("input is older than the max input age")
+	// ErrMissingInputOriginMilestone gets returned if the milestone which confirmed the creation of a consumed UTXO is not provided.
+	ErrMissingInputOriginMilestone = errors.New("missing origin milestone of input")
+	// ErrMaxInputsPerAddressExceeded gets returned if a transaction consumes more UTXOs of a single address than allowed.
+	ErrMaxInputsPerAddressExceeded = errors.New("max inputs per address exceeded")
+	// ErrUnlockRatioExceeded gets returned if the unlock blocks of a transaction are too big in relation to its essence.
+	ErrUnlockRatioExceeded = errors.New("max ratio of unlock blocks size to essence size exceeded")
+	// ErrUnknownTransactionFormatVersion gets returned if a versioned transaction envelope has an unknown format version.
+	ErrUnknownTransactionFormatVersion = errors.New("unknown transaction format version")
+	// ErrStopWalk can be returned by a TransactionVisitor to stop Transaction.Walk() without Walk() returning an error.
+	ErrStopWalk = errors.New("stop walk")
 )
 
 // TransactionID is the ID of a Transaction.
@@ -69,10 +105,128 @@ func (t *Transaction) ID() (*TransactionID, error) {
 	return &h, nil
 }
 
+// Size returns the size of the Transaction in its serialized form without serializing it.
+// Like TransactionEssence.Size, it panics if the size of an object can neither be told nor determined by serializing it.
+func (t *Transaction) Size() int {
+	size := TransactionBinSerializedMinSize + mustSerializedSize(t.Essence) + serializer.UInt16ByteSize
+	for _, unlockBlock := range t.UnlockBlocks {
+		size += mustSerializedSize(unlockBlock)
+	}
+	return size
+}
+
+// Equal tells whether the Transaction and other hold equal essences and unlock blocks in the same order.
+func (t *Transaction) Equal(other *Transaction) bool {
+	if t == nil || other == nil {
+		return t == other
+	}
+
+	if len(t.UnlockBlocks) != len(other.UnlockBlocks) {
+		return false
+	}
+
+	for i := range t.UnlockBlocks {
+		if !serializablesEqual(t.UnlockBlocks[i], other.UnlockBlocks[i]) {
+			return false
+		}
+	}
+
+	essence, isTxEssence := t.Essence.(*TransactionEssence)
+	otherEssence, otherIsTxEssence := other.Essence.(*TransactionEssence)
+	if isTxEssence && otherIsTxEssence {
+		return essence.Equal(otherEssence)
+	}
+	return serializablesEqual(t.Essence, other.Essence)
+}
+
+// Clone returns a deep copy of the Transaction.
+// An error is returned if the Transaction holds objects of unsupported types.
+func (t *Transaction) Clone() (*Transaction, error) {
+	cpy := &Transaction{}
+
+	switch essence := t.Essence.(type) {
+	case nil:
+	case *TransactionEssence:
+		essenceCpy, err := essence.Clone()
+		if err != nil {
+			return nil, err
+		}
+		cpy.Essence = essenceCpy
+	default:
+		return nil, fmt.Errorf("%w: unable to clone transaction essence of type %T", ErrUnknownTransactionEssenceType, t.Essence)
+	}
+
+	if t.UnlockBlocks != nil {
+		cpy.UnlockBlocks = make(serializer.Serializables, len(t.UnlockBlocks))
+		for i, unlockBlock := range t.UnlockBlocks {
+			switch ub := unlockBlock.(type) {
+			case *SignatureUnlockBlock:
+				switch sig := ub.Signature.(type) {
+				case *Ed25519Signature:
+					sigCpy := *sig
+					cpy.UnlockBlocks[i] = &SignatureUnlockBlock{Signature: &sigCpy}
+				default:
+					return nil, fmt.Errorf("%w: unable to clone signature of type %T at index %d", ErrUnknownSignatureType, ub.Signature, i)
+				}
+			case *ReferenceUnlockBlock:
+				cpy.UnlockBlocks[i] = &ReferenceUnlockBlock{Reference: ub.Reference}
+			default:
+				return nil, fmt.Errorf("%w: unable to clone unlock block of type %T at index %d", ErrUnknownUnlockBlockType, unlockBlock, i)
+			}
+		}
+	}
+
+	return cpy, nil
+}
+
+// TransactionHasher computes the TransactionID of a serialized Transaction which gets written to it in chunks,
+// without having to buffer the entire Transaction.
+type TransactionHasher struct {
+	h hash.Hash
+}
+
+// NewTransactionHasher creates a new TransactionHasher.
+func NewTransactionHasher() *TransactionHasher {
+	// blake2b.New256 only errors for keys longer than 64 bytes
+	h, _ := blake2b.New256(nil)
+	return &TransactionHasher{h: h}
+}
+
+// Write adds the next chunk of the serialized Transaction. It never returns an error.
+func (th *TransactionHasher) Write(p []byte) (int, error) {
+	return th.h.Write(p)
+}
+
+// Sum returns the TransactionID of the bytes written so far.
+func (th *TransactionHasher) Sum() TransactionID {
+	var txID TransactionID
+	copy(txID[:], th.h.Sum(nil))
+	return txID
+}
+
+// SigningMessageFromTransactionBytes computes the signing message of the essence of the given serialized Transaction,
+// as TransactionEssence.SigningMessage() would return it, without deserializing the unlock blocks of the Transaction.
+func SigningMessageFromTransactionBytes(data []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if err := serializer.CheckMinByteLength(TransactionBinSerializedMinSize, len(data)); err != nil {
+		return nil, fmt.Errorf("invalid transaction bytes: %w", err)
+	}
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+		if err := serializer.CheckType(data, TransactionPayloadTypeID); err != nil {
+			return nil, fmt.Errorf("unable to deserialize transaction: %w", err)
+		}
+	}
+
+	txEssence := &TransactionEssence{}
+	if _, err := txEssence.Deserialize(data[serializer.TypeDenotationByteSize:], deSeriMode); err != nil {
+		return nil, fmt.Errorf("%w: unable to deserialize transaction essence within transaction", err)
+	}
+	return txEssence.SigningMessage()
+}
+
 func (t *Transaction) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
 	unlockBlockArrayRules := &serializer.ArrayRules{}
 
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(TransactionBinSerializedMinSize, len(data)); err != nil {
@@ -105,6 +259,7 @@ func (t *Transaction) Deserialize(data []byte, deSeriMode serializer.DeSerializa
 			return nil
 		}).
 		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (t *Transaction) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
@@ -127,6 +282,39 @@ func (t *Transaction) Serialize(deSeriMode serializer.DeSerializationMode) ([]by
 		Serialize()
 }
 
+// SerializeVersioned serializes the Transaction into an envelope prefixed with the TransactionFormatVersion1 byte,
+// so that stored transactions can be migrated once the binary format changes. The Transaction is not validated.
+func (t *Transaction) SerializeVersioned() ([]byte, error) {
+	data, err := t.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte{TransactionFormatVersion1}, data...), nil
+}
+
+// DeserializeVersioned deserializes a Transaction from an envelope produced by Transaction.SerializeVersioned(),
+// dispatching on its format version byte. The Transaction is not validated.
+func DeserializeVersioned(data []byte) (*Transaction, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: versioned transaction envelope is empty", serializer.ErrDeserializationNotEnoughData)
+	}
+
+	switch version := data[0]; version {
+	case TransactionFormatVersion1:
+		tx := &Transaction{}
+		bytesRead, err := tx.Deserialize(data[1:], serializer.DeSeriModeNoValidation)
+		if err != nil {
+			return nil, err
+		}
+		if bytesRead != len(data)-1 {
+			return nil, fmt.Errorf("%w: %d bytes left after the transaction", serializer.ErrDeserializationNotAllConsumed, len(data)-1-bytesRead)
+		}
+		return tx, nil
+	default:
+		return nil, fmt.Errorf("%w: %d", ErrUnknownTransactionFormatVersion, version)
+	}
+}
+
 func (t *Transaction) MarshalJSON() ([]byte, error) {
 	jTransaction := &jsonTransaction{
 		UnlockBlocks: make([]*json.RawMessage, len(t.UnlockBlocks)),
@@ -149,6 +337,13 @@ func (t *Transaction) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jTransaction)
 }
 
+// MarshalJSONCanonical returns the JSON representation of the Transaction in a byte-stable form:
+// object keys are sorted and no insignificant whitespace is emitted. Inputs, outputs and unlock blocks
+// keep their order, as it is significant. The result can be decoded via UnmarshalJSON.
+func (t *Transaction) MarshalJSONCanonical() ([]byte, error) {
+	return canonicalJSON(t)
+}
+
 func (t *Transaction) UnmarshalJSON(bytes []byte) error {
 	jTransaction := &jsonTransaction{}
 	if err := json.Unmarshal(bytes, jTransaction); err != nil {
@@ -162,6 +357,149 @@ func (t *Transaction) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// TransactionVisitor is called by Transaction.Walk() for every input, output and unlock block of a Transaction.
+// Returning ErrStopWalk from any of its functions stops the walk, any other error aborts it.
+type TransactionVisitor interface {
+	// VisitInput is called with every input of the TransactionEssence and its index.
+	VisitInput(index int, input serializer.Serializable) error
+	// VisitOutput is called with every output of the TransactionEssence and its index.
+	VisitOutput(index int, output Output) error
+	// VisitUnlockBlock is called with every unlock block of the Transaction and its index.
+	VisitUnlockBlock(index int, unlockBlock serializer.Serializable) error
+}
+
+// Walk traverses the inputs, outputs and then unlock blocks of the Transaction in their order and
+// passes them to the given TransactionVisitor. If the visitor returns ErrStopWalk, Walk stops and returns nil.
+func (t *Transaction) Walk(visitor TransactionVisitor) error {
+	if err := t.walk(visitor); err != nil && !errors.Is(err, ErrStopWalk) {
+		return err
+	}
+	return nil
+}
+
+func (t *Transaction) walk(visitor TransactionVisitor) error {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	for i, input := range txEssence.Inputs {
+		if err := visitor.VisitInput(i, input); err != nil {
+			return err
+		}
+	}
+
+	for i, output := range txEssence.Outputs {
+		out, ok := output.(Output)
+		if !ok {
+			return fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i)
+		}
+		if err := visitor.VisitOutput(i, out); err != nil {
+			return err
+		}
+	}
+
+	for i, unlockBlock := range t.UnlockBlocks {
+		if err := visitor.VisitUnlockBlock(i, unlockBlock); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// InputIDs returns the IDs of the UTXOs consumed by this Transaction in input order.
+func (t *Transaction) InputIDs() (UTXOInputIDs, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	ids := make(UTXOInputIDs, len(txEssence.Inputs))
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+		}
+		ids[i] = in.ID()
+	}
+	return ids, nil
+}
+
+// MissingInputs returns the IDs of the UTXOs consumed by this Transaction which are not contained in inputs,
+// in input order. An empty result means that inputs can be passed to SemanticallyValidate() without it failing
+// with ErrMissingUTXO.
+func (t *Transaction) MissingInputs(inputs OutputSet) (UTXOInputIDs, error) {
+	inputIDs, err := t.InputIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	missing := make(UTXOInputIDs, 0)
+	for _, inputID := range inputIDs {
+		if _, has := inputs[inputID]; !has {
+			missing = append(missing, inputID)
+		}
+	}
+	return missing, nil
+}
+
+// OutputsSet returns an OutputSet of the outputs created by this Transaction,
+// identified by this Transaction's ID and their index within the TransactionEssence.
+func (t *Transaction) OutputsSet() (OutputSet, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	txID, err := t.ID()
+	if err != nil {
+		return nil, err
+	}
+
+	set := make(OutputSet, len(txEssence.Outputs))
+	for i, output := range txEssence.Outputs {
+		out, ok := output.(Output)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i)
+		}
+		utxoInput := &UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}
+		set[utxoInput.ID()] = out
+	}
+	return set, nil
+}
+
+// LedgerMutation describes the changes a Transaction applies to the ledger.
+type LedgerMutation struct {
+	// The IDs of the outputs consumed by the Transaction, in input order.
+	Consumed UTXOInputIDs
+	// The outputs created by the Transaction.
+	Created OutputSet
+}
+
+// LedgerMutation returns the LedgerMutation this Transaction applies to the ledger.
+// Every output consumed by the Transaction must be contained in inputs.
+// LedgerMutation does not validate the Transaction, SemanticallyValidate() should be used for that.
+func (t *Transaction) LedgerMutation(inputs OutputSet) (*LedgerMutation, error) {
+	consumed, err := t.InputIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, utxoID := range consumed {
+		if _, has := inputs[utxoID]; !has {
+			return nil, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+		}
+	}
+
+	created, err := t.OutputsSet()
+	if err != nil {
+		return nil, err
+	}
+
+	return &LedgerMutation{Consumed: consumed, Created: created}, nil
+}
+
 // SyntacticallyValidate syntactically validates the Transaction:
 //	1. The TransactionEssence isn't nil
 //	2. syntactic validation on the TransactionEssence
@@ -295,121 +633,782 @@ func NewDustSemanticValidation(div int64, dustOutputsCountLimit int64, dustAllow
 	}
 }
 
-// InputToOutputMapping maps inputs to their origin UTXOs.
-type InputToOutputMapping = map[UTXOInputID]Output
-
-// SemanticallyValidate semantically validates the Transaction
-// by checking that the given input UTXOs are spent entirely and the signatures
-// provided are valid. SyntacticallyValidate() should be called before SemanticallyValidate() to
-// ensure that the essence part of the transaction is syntactically valid.
-func (t *Transaction) SemanticallyValidate(utxos InputToOutputMapping, semValFuncs ...SemanticValidationFunc) error {
+// TxSemanticRecipientWhitelist returns a SemanticValidationFunc which verifies that every output
+// of a transaction deposits to an address within allowed. The map is keyed by the address' String() representation.
+func TxSemanticRecipientWhitelist(allowed map[string]bool) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		for i, output := range t.Essence.(*TransactionEssence).Outputs {
+			out, ok := output.(Output)
+			if !ok {
+				return fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i)
+			}
 
-	txEssence, ok := t.Essence.(*TransactionEssence)
-	if !ok {
-		return fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
-	}
+			target, err := out.Target()
+			if err != nil {
+				return fmt.Errorf("unable to get target of output at index %d: %w", i, err)
+			}
 
-	txEssenceBytes, err := txEssence.SigningMessage()
-	if err != nil {
-		return err
-	}
+			addr, isAddr := target.(Address)
+			if !isAddr {
+				return fmt.Errorf("%w: output at index %d does not deposit to an address", ErrOutputRecipientNotWhitelisted, i)
+			}
 
-	inputSum, sigValidFuncs, err := t.SemanticallyValidateInputs(utxos, txEssence, txEssenceBytes)
-	if err != nil {
-		return err
+			if !allowed[addr.String()] {
+				return fmt.Errorf("%w: output at index %d deposits to %s", ErrOutputRecipientNotWhitelisted, i, addr)
+			}
+		}
+		return nil
 	}
+}
 
-	outputSum, err := t.SemanticallyValidateOutputs(txEssence)
-	if err != nil {
-		return err
-	}
+// TxSemanticPayloadTagNamespace returns a SemanticValidationFunc which verifies that the index
+// of an embedded indexation payload starts with allowedPrefix. Transactions without an embedded payload pass.
+func TxSemanticPayloadTagNamespace(allowedPrefix []byte) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
+		if essence.Payload == nil {
+			return nil
+		}
 
-	if inputSum != outputSum {
-		return fmt.Errorf("%w: inputs sum %d, outputs sum %d", ErrInputOutputSumMismatch, inputSum, outputSum)
-	}
+		indexation, isIndexation := essence.Payload.(*Indexation)
+		if !isIndexation {
+			return fmt.Errorf("%w: embedded payload is of type %T", ErrEmbeddedPayloadTagNotAllowed, essence.Payload)
+		}
 
-	for _, semValFunc := range semValFuncs {
-		if err := semValFunc(t, utxos); err != nil {
-			return err
+		if !bytes.HasPrefix(indexation.Index, allowedPrefix) {
+			return fmt.Errorf("%w: index %x does not start with %x", ErrEmbeddedPayloadTagNotAllowed, indexation.Index, allowedPrefix)
 		}
+		return nil
 	}
+}
 
-	// sig verifications runs at the end as they are the most computationally expensive operation
-	for _, f := range sigValidFuncs {
-		if err := f(); err != nil {
-			return err
+// TxSemanticNoEmbeddedPayload returns a SemanticValidationFunc which verifies that
+// the transaction essence does not embed any payload.
+func TxSemanticNoEmbeddedPayload() SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
+		if essence.Payload != nil {
+			return fmt.Errorf("%w: essence embeds a payload of type %T", ErrEmbeddedPayloadNotAllowed, essence.Payload)
 		}
+		return nil
 	}
-
-	return nil
 }
 
-// SemanticallyValidateInputs checks that every referenced UTXO is available, computes the input sum
-// and returns functions which can be called to verify the signatures.
-// This function should only be called from SemanticallyValidate().
-func (t *Transaction) SemanticallyValidateInputs(utxos InputToOutputMapping, transaction *TransactionEssence, txEssenceBytes []byte) (uint64, []SigValidationFunc, error) {
-	var sigValidFuncs []SigValidationFunc
-	var inputSum uint64
-	seenInputAddr := make(map[string]int)
-
-	for i, input := range transaction.Inputs {
-		in, alreadySeen := input.(*UTXOInput)
-		if !alreadySeen {
-			return 0, nil, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+// TxSemanticMinFee returns a SemanticValidationFunc which verifies that the net amount
+// deposited to feeAddress by the transaction, as computed by Transaction.BalanceChanges(), is at least minFee.
+func TxSemanticMinFee(feeAddress Address, minFee uint64) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		changes, err := t.BalanceChanges(utxos)
+		if err != nil {
+			return err
 		}
 
-		// check that we got the needed UTXO
-		utxoID := in.ID()
-		utxo, has := utxos[utxoID]
-		if !has {
-			return 0, nil, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+		if fee := changes[feeAddress.String()]; fee < 0 || uint64(fee) < minFee {
+			return fmt.Errorf("%w: fee address %s receives %d but the minimum is %d", ErrFeeBelowMinimum, feeAddress, fee, minFee)
 		}
+		return nil
+	}
+}
 
-		var err error
-		deposit, err := utxo.Deposit()
+// TxSemanticMaxOutputConcentration returns a SemanticValidationFunc which verifies that
+// no single output deposits more than the given fraction of the sum of all outputs.
+func TxSemanticMaxOutputConcentration(fraction float64) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
+		outputSum, err := t.SemanticallyValidateOutputs(essence)
 		if err != nil {
-			return 0, nil, fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err)
+			return err
 		}
-		inputSum += deposit
 
-		sigBlock, sigBlockIndex, err := t.signatureUnlockBlock(i)
-		if err != nil {
-			return 0, nil, err
+		maxDeposit := fraction * float64(outputSum)
+		for i, output := range essence.Outputs {
+			deposit, err := output.(Output).Deposit()
+			if err != nil {
+				return fmt.Errorf("unable to get deposit of output at index %d: %w", i, err)
+			}
+			if float64(deposit) > maxDeposit {
+				return fmt.Errorf("%w: output at index %d deposits %d of %d (max fraction %f)", ErrOutputValueConcentrationExceeded, i, deposit, outputSum, fraction)
+			}
 		}
+		return nil
+	}
+}
 
-		target, err := utxo.Target()
-		if err != nil {
-			return 0, nil, fmt.Errorf("unable to get target for UTXO %v: %w", utxoID, err)
-		}
+// TxSemanticRequireChangeOutput returns a SemanticValidationFunc which verifies that
+// a transaction consuming inputs of changeAddr has at least one output depositing to changeAddr.
+func TxSemanticRequireChangeOutput(changeAddr Address) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
 
-		// change this logic here once we got tx output types without addrs
-		addr, isAddr := target.(Address)
-		if !isAddr {
-			return 0, nil, fmt.Errorf("target for UTXO %v must be an address: %w", utxoID, err)
-		}
+		var consumesChangeAddr bool
+		for i, input := range essence.Inputs {
+			in, ok := input.(*UTXOInput)
+			if !ok {
+				return fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+			}
 
-		usedSigBlockIndex, alreadySeen := seenInputAddr[addr.String()]
-		if alreadySeen {
-			if usedSigBlockIndex != sigBlockIndex {
-				return 0, nil, fmt.Errorf("%w: target for UTXO %v uses a different signature unlock block (%d) than a previous UTXO (%d) for the same address", ErrInputSignatureUnlockBlockInvalid, utxoID, sigBlockIndex, usedSigBlockIndex)
+			utxo, has := utxos[in.ID()]
+			if !has {
+				return fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, in.ID(), i)
+			}
+
+			addr, _, err := outputAddrAndDeposit(utxo)
+			if err != nil {
+				return fmt.Errorf("unable to get address of UTXO %v (input at index %d): %w", in.ID(), i, err)
+			}
+
+			if addr.String() == changeAddr.String() {
+				consumesChangeAddr = true
+				break
 			}
-			// we can skip here as we already created a sig validation func
-			continue
 		}
 
-		sigValidF, err := createSigValidationFunc(i, sigBlock.Signature, sigBlockIndex, txEssenceBytes, addr)
-		if err != nil {
-			return 0, nil, err
+		if !consumesChangeAddr {
+			return nil
 		}
 
-		seenInputAddr[addr.String()] = sigBlockIndex
+		for i, output := range essence.Outputs {
+			addr, _, err := outputAddrAndDeposit(output.(Output))
+			if err != nil {
+				return fmt.Errorf("unable to get address of output at index %d: %w", i, err)
+			}
+			if addr.String() == changeAddr.String() {
+				return nil
+			}
+		}
 
-		sigValidFuncs = append(sigValidFuncs, sigValidF)
+		return fmt.Errorf("%w: inputs of address %s are consumed but no output deposits to it", ErrChangeOutputMissing, changeAddr)
+	}
+}
+
+// TxSemanticMinTotalValue returns a SemanticValidationFunc which verifies that
+// the sum of the deposits of the UTXOs consumed by the transaction is at least min.
+func TxSemanticMinTotalValue(min uint64) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
+
+		var inputSum uint64
+		for i, input := range essence.Inputs {
+			in, ok := input.(*UTXOInput)
+			if !ok {
+				return fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+			}
+
+			utxo, has := utxos[in.ID()]
+			if !has {
+				return fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, in.ID(), i)
+			}
+
+			deposit, err := utxo.Deposit()
+			if err != nil {
+				return fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", in.ID(), i, err)
+			}
+			inputSum += deposit
+		}
+
+		if inputSum < min {
+			return fmt.Errorf("%w: transaction consumes %d but the minimum is %d", ErrTotalValueBelowMinimum, inputSum, min)
+		}
+		return nil
+	}
+}
+
+// TxSemanticMaxInputAge returns a SemanticValidationFunc which verifies that no UTXO consumed by the transaction
+// was created more than maxAge milestones before the milestone confirmingMilestoneIndex which confirms the transaction.
+// originMilestones must hold the index of the milestone which confirmed the creation of each consumed UTXO.
+func TxSemanticMaxInputAge(maxAge uint32, confirmingMilestoneIndex uint32, originMilestones map[UTXOInputID]uint32) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
+
+		for i, input := range essence.Inputs {
+			in, ok := input.(*UTXOInput)
+			if !ok {
+				return fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+			}
+
+			originMilestoneIndex, has := originMilestones[in.ID()]
+			if !has {
+				return inputSemanticError(i, fmt.Errorf("%w: origin milestone of UTXO %v is not provided (input at index %d)", ErrMissingInputOriginMilestone, in.ID(), i))
+			}
+
+			if originMilestoneIndex < confirmingMilestoneIndex && confirmingMilestoneIndex-originMilestoneIndex > maxAge {
+				return inputSemanticError(i, fmt.Errorf("%w: UTXO %v (input at index %d) was created at milestone %d, %d milestones before %d (max age %d)",
+					ErrInputTooOld, in.ID(), i, originMilestoneIndex, confirmingMilestoneIndex-originMilestoneIndex, confirmingMilestoneIndex, maxAge))
+			}
+		}
+		return nil
+	}
+}
+
+// TxSemanticMaxInputsPerAddress returns a SemanticValidationFunc which verifies that
+// the transaction consumes at most max UTXOs residing on the same address.
+func TxSemanticMaxInputsPerAddress(max int) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essence := t.Essence.(*TransactionEssence)
+
+		inputsPerAddr := make(map[string]int)
+		for i, input := range essence.Inputs {
+			in, ok := input.(*UTXOInput)
+			if !ok {
+				return fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+			}
+
+			utxo, has := utxos[in.ID()]
+			if !has {
+				return fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, in.ID(), i)
+			}
+
+			addr, _, err := outputAddrAndDeposit(utxo)
+			if err != nil {
+				return fmt.Errorf("unable to get address of UTXO %v (input at index %d): %w", in.ID(), i, err)
+			}
+
+			inputsPerAddr[addr.String()]++
+			if inputsPerAddr[addr.String()] > max {
+				return inputSemanticError(i, fmt.Errorf("%w: input at index %d is the UTXO number %d of address %s (max %d)", ErrMaxInputsPerAddressExceeded, i, inputsPerAddr[addr.String()], addr, max))
+			}
+		}
+		return nil
+	}
+}
+
+// TxSemanticMaxUnlockRatio returns a SemanticValidationFunc which verifies that the serialized size of
+// the transaction's unlock blocks, including their count prefix, is at most maxRatio times the serialized size of its essence.
+// Inputs residing on the same address share a signature through reference unlock blocks and hence add little to the ratio.
+func TxSemanticMaxUnlockRatio(maxRatio float64) SemanticValidationFunc {
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		essenceSize, err := serializedSize(t.Essence)
+		if err != nil {
+			return err
+		}
+		unlockBlocksSize := serializer.UInt16ByteSize
+		for _, unlockBlock := range t.UnlockBlocks {
+			unlockBlockSize, err := serializedSize(unlockBlock)
+			if err != nil {
+				return err
+			}
+			unlockBlocksSize += unlockBlockSize
+		}
+
+		if ratio := float64(unlockBlocksSize) / float64(essenceSize); ratio > maxRatio {
+			return fmt.Errorf("%w: unlock blocks size %d, essence size %d, ratio %f (max %f)", ErrUnlockRatioExceeded, unlockBlocksSize, essenceSize, ratio, maxRatio)
+		}
+		return nil
+	}
+}
+
+// MetricsSink receives metrics about the execution of SemanticValidationFunc(s).
+type MetricsSink interface {
+	// RecordFailure is called with the name of the step whose SemanticValidationFunc returned an error.
+	RecordFailure(step string)
+	// RecordDuration is called with the name of the step and the time its SemanticValidationFunc took to run.
+	RecordDuration(step string, d time.Duration)
+}
+
+// TxSemanticWithMetrics wraps the given SemanticValidationFunc so that its duration and failures
+// are recorded on the given MetricsSink under the given step name.
+// If sink is nil, semValFunc is returned as is.
+func TxSemanticWithMetrics(step string, sink MetricsSink, semValFunc SemanticValidationFunc) SemanticValidationFunc {
+	if sink == nil {
+		return semValFunc
+	}
+	return func(t *Transaction, utxos InputToOutputMapping) error {
+		s := time.Now()
+		err := semValFunc(t, utxos)
+		sink.RecordDuration(step, time.Since(s))
+		if err != nil {
+			sink.RecordFailure(step)
+		}
+		return err
+	}
+}
+
+// InputToOutputMapping maps inputs to their origin UTXOs.
+type InputToOutputMapping = map[UTXOInputID]Output
+
+// the steps of the semantic validation in the order they are run in.
+var semanticValidationOrder = []string{
+	"essence type",
+	"unlock blocks count",
+	"essence syntax",
+	"inputs",
+	"balance",
+	"semantic validation funcs",
+	"signatures",
+}
+
+// SemanticValidationOrder returns the names of the steps run by SemanticallyValidate in the order they are run in.
+// When multiple checks fail, SemanticallyValidate returns the error of the earliest failing step.
+// The order is part of the API and does not change between versions.
+func SemanticValidationOrder() []string {
+	order := make([]string, len(semanticValidationOrder))
+	copy(order, semanticValidationOrder)
+	return order
+}
+
+// SemanticallyValidate semantically validates the Transaction
+// by checking that the given input UTXOs are spent entirely and the signatures
+// provided are valid. SyntacticallyValidate() should be called before SemanticallyValidate() to
+// ensure that the essence part of the transaction is syntactically valid.
+// Errors caused by a specific input or output are returned as *SemanticError carrying its index.
+// The checks run in the following order and the first failing one aborts the validation (see SemanticValidationOrder()):
+//	1. essence type: the essence must be a *TransactionEssence
+//	2. unlock blocks count: the count of unlock blocks must match the count of inputs
+//	3. essence syntax: the essence must be syntactically valid in order to compute its signing message
+//	4. inputs: every input's UTXO must be provided and unlocked by a fitting unlock block, in the order of the inputs
+//	5. balance: the inputs and outputs must spend/deposit the same amount
+//	6. semantic validation funcs: the given SemanticValidationFunc(s), in the order they are passed
+//	7. signatures: every signature must be valid, in the order of the inputs.
+func (t *Transaction) SemanticallyValidate(utxos InputToOutputMapping, semValFuncs ...SemanticValidationFunc) error {
+	return t.SemanticallyValidateWithVerifier(utxos, DefaultSignatureVerifier, semValFuncs...)
+}
+
+// SemanticallyValidateWithVerifier is like SemanticallyValidate but delegates the verification of signatures
+// to the given SignatureVerifier. SemanticallyValidateWithVerifier does not call verifier.Flush(): if the verifier
+// defers the verification of signatures, the Transaction is only valid once verifier.Flush() returned without an error.
+func (t *Transaction) SemanticallyValidateWithVerifier(utxos InputToOutputMapping, verifier SignatureVerifier, semValFuncs ...SemanticValidationFunc) error {
+	return t.semanticallyValidate(context.Background(), utxos, verifier, nil, semValFuncs...)
+}
+
+// SemanticallyValidateParallel is like SemanticallyValidate but verifies the signatures of the Transaction
+// concurrently using the given amount of workers. The resolution of reference unlock blocks is still done in order
+// before any signature is verified, hence SemanticallyValidateParallel accepts and rejects exactly the same
+// transactions as SemanticallyValidate. An invalid signature is reported as a *SemanticError carrying the same
+// input index as the one returned by SemanticallyValidate.
+func (t *Transaction) SemanticallyValidateParallel(utxos InputToOutputMapping, workers int, semValFuncs ...SemanticValidationFunc) error {
+	verifier := NewParallelSignatureVerifier(workers)
+	if err := t.SemanticallyValidateWithVerifier(utxos, verifier, semValFuncs...); err != nil {
+		return err
+	}
+	return verifier.Flush()
+}
+
+// SemanticallyValidateContext is like SemanticallyValidate but aborts with ctx.Err() once the given context is done.
+// The context is checked before every input, before every SemanticValidationFunc and before the verification
+// of every signature. No goroutines are started.
+func (t *Transaction) SemanticallyValidateContext(ctx context.Context, utxos InputToOutputMapping, semValFuncs ...SemanticValidationFunc) error {
+	return t.semanticallyValidate(ctx, utxos, DefaultSignatureVerifier, nil, semValFuncs...)
+}
+
+// SemanticallyValidateAll is like SemanticallyValidate but does not stop at the first failing check:
+// it returns a *SemanticValidationErrors holding the errors of all checks which failed.
+// The checks are collected as follows:
+//	1. the resolution of the inputs' UTXOs and unlock blocks is short-circuited: its error is returned as is,
+//	   since all further checks depend on it
+//	2. the output deposits and the balance of inputs and outputs are collected into Balance
+//	3. every SemanticValidationFunc runs and its error is collected into SemanticValidationFuncs
+//	4. every signature is verified and its error is collected into Signatures.
+func (t *Transaction) SemanticallyValidateAll(utxos InputToOutputMapping, semValFuncs ...SemanticValidationFunc) error {
+	return t.semanticallyValidate(context.Background(), utxos, DefaultSignatureVerifier, &SemanticValidationErrors{}, semValFuncs...)
+}
+
+// runs the semantic validation in the order documented on SemanticallyValidate. The validation aborts with ctx.Err()
+// once ctx is done. If errs is nil, the validation stops at the first failing check. Otherwise, the errors of
+// the balance, SemanticValidationFunc and signature checks are collected into errs, which is returned
+// if any of them failed, while all prior checks still stop the validation.
+func (t *Transaction) semanticallyValidate(ctx context.Context, utxos InputToOutputMapping, verifier SignatureVerifier, errs *SemanticValidationErrors, semValFuncs ...SemanticValidationFunc) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	// guards against transactions which did not go through SyntacticallyValidate()
+	if inputCount, unlockBlockCount := len(txEssence.Inputs), len(t.UnlockBlocks); inputCount != unlockBlockCount {
+		return fmt.Errorf("%w: num of inputs %d, num of unlock blocks %d", ErrUnlockBlocksMustMatchInputCount, inputCount, unlockBlockCount)
+	}
+
+	txEssenceBytes, err := txEssence.SigningMessage()
+	if err != nil {
+		return err
+	}
+
+	inputSum, sigValidFuncs, err := t.semanticallyValidateInputs(ctx, utxos, txEssence, txEssenceBytes, verifier)
+	if err != nil {
+		return err
+	}
+
+	if err := t.semanticallyValidateBalance(ctx, utxos, txEssence, inputSum, errs, semValFuncs...); err != nil {
+		return err
+	}
+
+	// sig verifications runs at the end as they are the most computationally expensive operation
+	for _, f := range sigValidFuncs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := f(); err != nil {
+			if errs == nil {
+				return err
+			}
+			errs.Signatures = append(errs.Signatures, err)
+		}
+	}
+
+	if errs == nil || errs.empty() {
+		return nil
+	}
+	return errs
+}
+
+// SemanticallyValidateStructure runs the same checks as SemanticallyValidate, except that the unlock blocks
+// and signatures of the Transaction are neither resolved nor verified. This allows to preview the ledger effects
+// of an unsigned or partially signed transaction.
+// A transaction passing SemanticallyValidateStructure must not be considered valid:
+// only SemanticallyValidate is sufficient for a transaction to be accepted.
+func (t *Transaction) SemanticallyValidateStructure(utxos InputToOutputMapping, semValFuncs ...SemanticValidationFunc) error {
+
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	var inputSum uint64
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			return fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+		}
+
+		utxoID := in.ID()
+		utxo, has := utxos[utxoID]
+		if !has {
+			return fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+		}
+
+		deposit, err := utxo.Deposit()
+		if err != nil {
+			return fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err)
+		}
+		inputSum += deposit
+	}
+
+	return t.semanticallyValidateBalance(context.Background(), utxos, txEssence, inputSum, nil, semValFuncs...)
+}
+
+// checks that the outputs spend the given input sum entirely and runs the given SemanticValidationFunc(s).
+// If errs is not nil, the errors are collected into it instead of being returned.
+func (t *Transaction) semanticallyValidateBalance(ctx context.Context, utxos InputToOutputMapping, txEssence *TransactionEssence, inputSum uint64, errs *SemanticValidationErrors, semValFuncs ...SemanticValidationFunc) error {
+	outputSum, err := t.SemanticallyValidateOutputs(txEssence)
+	if err == nil && inputSum != outputSum {
+		err = fmt.Errorf("%w: inputs sum %d, outputs sum %d", ErrInputOutputSumMismatch, inputSum, outputSum)
+	}
+	if err != nil {
+		if errs == nil {
+			return err
+		}
+		errs.Balance = append(errs.Balance, err)
+	}
+
+	for _, semValFunc := range semValFuncs {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := semValFunc(t, utxos); err != nil {
+			if errs == nil {
+				return err
+			}
+			errs.SemanticValidationFuncs = append(errs.SemanticValidationFuncs, err)
+		}
+	}
+
+	return nil
+}
+
+// SemanticallyValidateInputs checks that every referenced UTXO is available, computes the input sum
+// and returns functions which can be called to verify the signatures.
+// All inputs belonging to the same address must be unlocked through the first signature unlock block
+// of that address: a further signature unlock block for the address, or a reference unlock block pointing
+// to one, results in an ErrInputSignatureUnlockBlockInvalid.
+// This function should only be called from SemanticallyValidate().
+func (t *Transaction) SemanticallyValidateInputs(utxos InputToOutputMapping, transaction *TransactionEssence, txEssenceBytes []byte) (uint64, []SigValidationFunc, error) {
+	return t.semanticallyValidateInputs(context.Background(), utxos, transaction, txEssenceBytes, DefaultSignatureVerifier)
+}
+
+func (t *Transaction) semanticallyValidateInputs(ctx context.Context, utxos InputToOutputMapping, transaction *TransactionEssence, txEssenceBytes []byte, verifier SignatureVerifier) (uint64, []SigValidationFunc, error) {
+	var sigValidFuncs []SigValidationFunc
+	var inputSum uint64
+	seenInputAddr := make(map[string]int)
+
+	for i, input := range transaction.Inputs {
+		if err := ctx.Err(); err != nil {
+			return 0, nil, err
+		}
+
+		in, alreadySeen := input.(*UTXOInput)
+		if !alreadySeen {
+			return 0, nil, inputSemanticError(i, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i))
+		}
+
+		// check that we got the needed UTXO
+		utxoID := in.ID()
+		utxo, has := utxos[utxoID]
+		if !has {
+			return 0, nil, inputSemanticError(i, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i))
+		}
+
+		var err error
+		deposit, err := utxo.Deposit()
+		if err != nil {
+			return 0, nil, inputSemanticError(i, fmt.Errorf("unable to get deposit from UTXO %v (input at index %d): %w", utxoID, i, err))
+		}
+		inputSum += deposit
+
+		sigBlock, sigBlockIndex, err := t.signatureUnlockBlock(i)
+		if err != nil {
+			return 0, nil, inputSemanticError(i, err)
+		}
+
+		target, err := utxo.Target()
+		if err != nil {
+			return 0, nil, inputSemanticError(i, fmt.Errorf("unable to get target for UTXO %v: %w", utxoID, err))
+		}
+
+		// change this logic here once we got tx output types without addrs
+		addr, isAddr := target.(Address)
+		if !isAddr {
+			return 0, nil, inputSemanticError(i, fmt.Errorf("target for UTXO %v must be an address: %w", utxoID, err))
+		}
+
+		usedSigBlockIndex, alreadySeen := seenInputAddr[addr.String()]
+		if alreadySeen {
+			if usedSigBlockIndex != sigBlockIndex {
+				return 0, nil, inputSemanticError(i, fmt.Errorf("%w: target for UTXO %v uses a different signature unlock block (%d) than a previous UTXO (%d) for the same address", ErrInputSignatureUnlockBlockInvalid, utxoID, sigBlockIndex, usedSigBlockIndex))
+			}
+			// we can skip here as we already created a sig validation func
+			continue
+		}
+
+		sigValidF, err := createSigValidationFunc(i, sigBlock.Signature, sigBlockIndex, txEssenceBytes, addr, verifier)
+		if err != nil {
+			return 0, nil, inputSemanticError(i, err)
+		}
+
+		seenInputAddr[addr.String()] = sigBlockIndex
+
+		sigValidFuncs = append(sigValidFuncs, sigValidF)
 	}
 
 	return inputSum, sigValidFuncs, nil
 }
 
+// PreflightUnlocks checks whether the unlock blocks of the Transaction fit the addresses of the inputs it consumes,
+// without verifying any signature. It fails if:
+//	1. the count of unlock blocks does not match the count of inputs
+//	2. a reference unlock block does not reference a previous signature unlock block
+//	3. an unlock block unlocks inputs of different addresses
+//	4. inputs of the same address are not all unlocked through the same signature unlock block
+//	5. the public key of an Ed25519 signature does not correspond to the address it unlocks.
+// PreflightUnlocks is a cheap check to fail fast and does not replace SemanticallyValidate().
+func (t *Transaction) PreflightUnlocks(inputs OutputSet) error {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	if inputCount, unlockBlockCount := len(txEssence.Inputs), len(t.UnlockBlocks); inputCount != unlockBlockCount {
+		return fmt.Errorf("%w: num of inputs %d, num of unlock blocks %d", ErrUnlockBlocksMustMatchInputCount, inputCount, unlockBlockCount)
+	}
+
+	sigBlockAddr := make(map[int]string)
+	seenInputAddr := make(map[string]int)
+
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			return fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+		}
+
+		utxoID := in.ID()
+		utxo, has := inputs[utxoID]
+		if !has {
+			return fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+		}
+
+		addr, _, err := outputAddrAndDeposit(utxo)
+		if err != nil {
+			return fmt.Errorf("unable to get address of UTXO %v (input at index %d): %w", utxoID, i, err)
+		}
+
+		var sigBlockIndex int
+		switch ub := t.UnlockBlocks[i].(type) {
+		case *SignatureUnlockBlock:
+			sigBlockIndex = i
+			if ed25519Sig, isEd25519Sig := ub.Signature.(*Ed25519Signature); isEd25519Sig {
+				if addrFromPubKey := AddressFromEd25519PubKey(ed25519Sig.PublicKey[:]); addrFromPubKey.String() != addr.String() {
+					return fmt.Errorf("%w: input at index %d has address %s but its signature's public key corresponds to %s", ErrEd25519PubKeyAndAddrMismatch, i, addr, &addrFromPubKey)
+				}
+			}
+		case *ReferenceUnlockBlock:
+			sigBlockIndex = int(ub.Reference)
+			if _, isSigBlock := sigBlockAddr[sigBlockIndex]; !isSigBlock || sigBlockIndex >= i {
+				return fmt.Errorf("%w: %d references non existent unlock block %d", ErrRefUnlockBlockInvalidRef, i, sigBlockIndex)
+			}
+		default:
+			return fmt.Errorf("%w: unsupported unlock block type at index %d", ErrUnknownUnlockBlockType, i)
+		}
+
+		if owner, has := sigBlockAddr[sigBlockIndex]; has && owner != addr.String() {
+			return fmt.Errorf("%w: input at index %d has address %s but its signature unlock block (%d) unlocks %s", ErrInputSignatureUnlockBlockInvalid, i, addr, sigBlockIndex, owner)
+		}
+
+		if usedSigBlockIndex, alreadySeen := seenInputAddr[addr.String()]; alreadySeen && usedSigBlockIndex != sigBlockIndex {
+			return fmt.Errorf("%w: input at index %d uses a different signature unlock block (%d) than a previous input (%d) for the same address", ErrInputSignatureUnlockBlockInvalid, i, sigBlockIndex, usedSigBlockIndex)
+		}
+
+		sigBlockAddr[sigBlockIndex] = addr.String()
+		seenInputAddr[addr.String()] = sigBlockIndex
+	}
+
+	return nil
+}
+
+// RequiredSigners returns the distinct addresses which must produce a signature in order to unlock
+// the inputs of the Transaction, in the order in which they first appear within the inputs.
+// The given OutputSet must contain the outputs referenced by the inputs.
+func (t *Transaction) RequiredSigners(inputs OutputSet) ([]Address, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	signers := make([]Address, 0)
+	seen := make(map[string]struct{})
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+		}
+
+		utxoID := in.ID()
+		utxo, has := inputs[utxoID]
+		if !has {
+			return nil, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+		}
+
+		addr, _, err := outputAddrAndDeposit(utxo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get address of UTXO %v (input at index %d): %w", utxoID, i, err)
+		}
+
+		if _, has := seen[addr.String()]; has {
+			continue
+		}
+		seen[addr.String()] = struct{}{}
+		signers = append(signers, addr)
+	}
+
+	return signers, nil
+}
+
+// ReferencedAddresses returns the distinct addresses the outputs of the Transaction deposit to,
+// in the order in which they first appear within the outputs.
+// Outputs without a target address are skipped.
+// This allows to tell whether a Transaction is relevant to a set of addresses without validating it.
+func (t *Transaction) ReferencedAddresses() ([]Address, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	addrs := make([]Address, 0)
+	seen := make(map[string]struct{})
+	for i, output := range txEssence.Outputs {
+		out, ok := output.(Output)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i)
+		}
+
+		target, err := out.Target()
+		if err != nil {
+			return nil, fmt.Errorf("unable to get target of output at index %d: %w", i, err)
+		}
+
+		addr, isAddr := target.(Address)
+		if !isAddr {
+			continue
+		}
+
+		if _, has := seen[addr.String()]; has {
+			continue
+		}
+		seen[addr.String()] = struct{}{}
+		addrs = append(addrs, addr)
+	}
+
+	return addrs, nil
+}
+
+// AmbiguityWarning describes a group of inputs and outputs of a Transaction which are structurally identical,
+// meaning that the consumed UTXOs and the outputs deposit the same amount onto the same address through the same type of output.
+// As more than one input can be paired with an output of the group, it is ambiguous which input an output carries on.
+type AmbiguityWarning struct {
+	// The indices of the inputs consuming the structurally identical UTXOs.
+	InputIndices []int
+	// The indices of the structurally identical outputs.
+	OutputIndices []int
+}
+
+// AmbiguousMappings returns an AmbiguityWarning for every group of structurally identical inputs and outputs of the Transaction
+// which holds at least one input, one output and more than one of either, in the order of the groups' first input.
+// It is meant as a diagnostic for accounting tools: inputs whose UTXO is not contained in the given OutputSet
+// and objects which can not be serialized are skipped.
+func (t *Transaction) AmbiguousMappings(inputs OutputSet) []AmbiguityWarning {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil
+	}
+
+	var groups []AmbiguityWarning
+	// maps the serialized form of an output to the position of its group within groups
+	groupIndices := make(map[string]int)
+
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			continue
+		}
+		utxo, has := inputs[in.ID()]
+		if !has {
+			continue
+		}
+		utxoBytes, err := utxo.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			continue
+		}
+		groupIndex, has := groupIndices[string(utxoBytes)]
+		if !has {
+			groupIndex = len(groups)
+			groupIndices[string(utxoBytes)] = groupIndex
+			groups = append(groups, AmbiguityWarning{})
+		}
+		groups[groupIndex].InputIndices = append(groups[groupIndex].InputIndices, i)
+	}
+
+	for i, output := range txEssence.Outputs {
+		outputBytes, err := output.Serialize(serializer.DeSeriModeNoValidation)
+		if err != nil {
+			continue
+		}
+		// outputs not matching any input are unambiguous
+		if groupIndex, has := groupIndices[string(outputBytes)]; has {
+			groups[groupIndex].OutputIndices = append(groups[groupIndex].OutputIndices, i)
+		}
+	}
+
+	var warnings []AmbiguityWarning
+	for _, group := range groups {
+		if len(group.OutputIndices) == 0 || len(group.InputIndices)+len(group.OutputIndices) <= 2 {
+			continue
+		}
+		warnings = append(warnings, group)
+	}
+	return warnings
+}
+
 // retrieves the SignatureUnlockBlock at the given index or follows
 // the reference of an ReferenceUnlockBlock to retrieve it.
 func (t *Transaction) signatureUnlockBlock(index int) (*SignatureUnlockBlock, int, error) {
@@ -428,30 +1427,45 @@ func (t *Transaction) signatureUnlockBlock(index int) (*SignatureUnlockBlock, in
 }
 
 // creates a SigValidationFunc appropriate for the underlying signature type.
-func createSigValidationFunc(pos int, sig serializer.Serializable, sigBlockIndex int, txEssenceBytes []byte, addr Address) (SigValidationFunc, error) {
+func createSigValidationFunc(pos int, sig serializer.Serializable, sigBlockIndex int, txEssenceBytes []byte, addr Address, verifier SignatureVerifier) (SigValidationFunc, error) {
 	switch addr := addr.(type) {
 	case *Ed25519Address:
-		return createEd25519SigValidationFunc(pos, sig, sigBlockIndex, addr, txEssenceBytes)
+		return createEd25519SigValidationFunc(pos, sig, sigBlockIndex, addr, txEssenceBytes, verifier)
 	default:
 		return nil, fmt.Errorf("%w: unsupported address type at index %d", ErrUnknownAddrType, pos)
 	}
 }
 
-// creates a SigValidationFunc validating the given Ed25519Signature against the Ed25519Address.
-func createEd25519SigValidationFunc(pos int, sig serializer.Serializable, sigBlockIndex int, addr *Ed25519Address, essenceBytes []byte) (SigValidationFunc, error) {
+// creates a SigValidationFunc validating the given Ed25519Signature against the Ed25519Address through the SignatureVerifier.
+func createEd25519SigValidationFunc(pos int, sig serializer.Serializable, sigBlockIndex int, addr *Ed25519Address, essenceBytes []byte, verifier SignatureVerifier) (SigValidationFunc, error) {
 	ed25519Sig, isEd25519Sig := sig.(*Ed25519Signature)
 	if !isEd25519Sig {
 		return nil, fmt.Errorf("%w: UTXO at index %d has an Ed25519 address but its corresponding signature is of type %T (at index %d)", ErrSignatureAndAddrIncompatible, pos, sig, sigBlockIndex)
 	}
 
+	if !ed25519Sig.Canonical() {
+		return nil, fmt.Errorf("%w: UTXO at index %d has an Ed25519 signature which is not canonically encoded (at index %d)", ErrSignatureAndAddrIncompatible, pos, sigBlockIndex)
+	}
+
 	return func() error {
-		if err := ed25519Sig.Valid(essenceBytes, addr); err != nil {
-			return fmt.Errorf("%w: input at index %d, signature block at index %d", err, pos, sigBlockIndex)
+		var err error
+		if inputVerifier, isInputVerifier := verifier.(inputSignatureVerifier); isInputVerifier {
+			err = inputVerifier.verifyInput(pos, sigBlockIndex, essenceBytes, ed25519Sig, addr)
+		} else {
+			err = verifier.Verify(essenceBytes, ed25519Sig, addr)
+		}
+		if err != nil {
+			return signatureVerificationError(pos, sigBlockIndex, err)
 		}
 		return nil
 	}, nil
 }
 
+// wraps the error of the verification of the signature unlocking the input at the given index.
+func signatureVerificationError(pos int, sigBlockIndex int, err error) error {
+	return inputSemanticError(pos, fmt.Errorf("%w: input at index %d, signature block at index %d", err, pos, sigBlockIndex))
+}
+
 // SemanticallyValidateOutputs accumulates the sum of all outputs.
 // This function should only be called from SemanticallyValidate().
 func (t *Transaction) SemanticallyValidateOutputs(transaction *TransactionEssence) (uint64, error) {
@@ -459,11 +1473,11 @@ func (t *Transaction) SemanticallyValidateOutputs(transaction *TransactionEssenc
 	for i, output := range transaction.Outputs {
 		out, ok := output.(Output)
 		if !ok {
-			return 0, fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i)
+			return 0, outputSemanticError(i, fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i))
 		}
 		deposit, err := out.Deposit()
 		if err != nil {
-			return 0, fmt.Errorf("unable to get deposit from output at index %d: %w", i, err)
+			return 0, outputSemanticError(i, fmt.Errorf("unable to get deposit from output at index %d: %w", i, err))
 		}
 		outputSum += deposit
 	}
@@ -471,6 +1485,172 @@ func (t *Transaction) SemanticallyValidateOutputs(transaction *TransactionEssenc
 	return outputSum, nil
 }
 
+// BalanceChanges computes the net balance change per address caused by the Transaction,
+// keyed by the address' String() representation. Deposits onto an address count positive,
+// the consumption of UTXOs residing on it negative. Addresses which net out to zero are included.
+func (t *Transaction) BalanceChanges(utxos InputToOutputMapping) (map[string]int64, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	changes := make(map[string]int64)
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, i)
+		}
+
+		utxoID := in.ID()
+		utxo, has := utxos[utxoID]
+		if !has {
+			return nil, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, i)
+		}
+
+		addr, deposit, err := outputAddrAndDeposit(utxo)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get address and deposit of UTXO %v (input at index %d): %w", utxoID, i, err)
+		}
+		changes[addr.String()] -= int64(deposit)
+	}
+
+	for i, output := range txEssence.Outputs {
+		out, ok := output.(Output)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported output type at index %d", ErrUnknownOutputType, i)
+		}
+
+		addr, deposit, err := outputAddrAndDeposit(out)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get address and deposit of output at index %d: %w", i, err)
+		}
+		changes[addr.String()] += int64(deposit)
+	}
+
+	return changes, nil
+}
+
+// returns the target address and the deposit of the given output.
+func outputAddrAndDeposit(output Output) (Address, uint64, error) {
+	deposit, err := output.Deposit()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	target, err := output.Target()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	addr, isAddr := target.(Address)
+	if !isAddr {
+		return nil, 0, fmt.Errorf("%w: target of %T is not an address", ErrUnknownAddrType, output)
+	}
+
+	return addr, deposit, nil
+}
+
+// Summary returns a human-readable, multi-line description of the Transaction listing its inputs
+// (resolved through the given UTXOs), outputs, embedded payload and the resulting balance changes per address.
+// It is meant for diagnostic purposes only, the format is not stable.
+func (t *Transaction) Summary(utxos InputToOutputMapping) string {
+	var b strings.Builder
+
+	txID, err := t.ID()
+	if err != nil {
+		fmt.Fprintf(&b, "Transaction <%s>\n", err)
+	} else {
+		fmt.Fprintf(&b, "Transaction %x\n", *txID)
+	}
+
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		fmt.Fprintf(&b, "  unsupported essence type %T\n", t.Essence)
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "Inputs (%d):\n", len(txEssence.Inputs))
+	for i, input := range txEssence.Inputs {
+		in, ok := input.(*UTXOInput)
+		if !ok {
+			fmt.Fprintf(&b, "  [%d] unsupported input type %T\n", i, input)
+			continue
+		}
+		utxo, has := utxos[in.ID()]
+		if !has {
+			fmt.Fprintf(&b, "  [%d] %s <missing UTXO>\n", i, in.ID().ToHex())
+			continue
+		}
+		fmt.Fprintf(&b, "  [%d] %s %s\n", i, in.ID().ToHex(), outputSummary(utxo))
+	}
+
+	fmt.Fprintf(&b, "Outputs (%d):\n", len(txEssence.Outputs))
+	for i, output := range txEssence.Outputs {
+		out, ok := output.(Output)
+		if !ok {
+			fmt.Fprintf(&b, "  [%d] unsupported output type %T\n", i, output)
+			continue
+		}
+		fmt.Fprintf(&b, "  [%d] %s\n", i, outputSummary(out))
+	}
+
+	switch payload := txEssence.Payload.(type) {
+	case nil:
+		b.WriteString("Payload: none\n")
+	case *Indexation:
+		fmt.Fprintf(&b, "Payload: indexation, index %x, %d bytes of data\n", payload.Index, len(payload.Data))
+	default:
+		fmt.Fprintf(&b, "Payload: unsupported payload type %T\n", payload)
+	}
+
+	changes, err := t.BalanceChanges(utxos)
+	if err != nil {
+		fmt.Fprintf(&b, "Balance changes: <%s>\n", err)
+		return b.String()
+	}
+
+	addrs := make([]string, 0, len(changes))
+	for addr := range changes {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	fmt.Fprintf(&b, "Balance changes (%d):\n", len(changes))
+	for _, addr := range addrs {
+		fmt.Fprintf(&b, "  %s %+d\n", addr, changes[addr])
+	}
+
+	return b.String()
+}
+
+// returns a one line description of the given output.
+func outputSummary(output Output) string {
+	var name string
+	switch output.(type) {
+	case *SigLockedSingleOutput:
+		name = "SigLockedSingleOutput"
+	case *SigLockedDustAllowanceOutput:
+		name = "SigLockedDustAllowanceOutput"
+	default:
+		name = fmt.Sprintf("%T", output)
+	}
+
+	deposit, err := output.Deposit()
+	if err != nil {
+		return fmt.Sprintf("%s <%s>", name, err)
+	}
+
+	target, err := output.Target()
+	if err != nil {
+		return fmt.Sprintf("%s <%s>", name, err)
+	}
+
+	if addr, isAddr := target.(Address); isAddr {
+		return fmt.Sprintf("%s %s %d", name, addr, deposit)
+	}
+	return fmt.Sprintf("%s %d", name, deposit)
+}
+
 // jsonTransaction defines the json representation of a Transaction.
 type jsonTransaction struct {
 	Type         int                `json:"type"`
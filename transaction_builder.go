@@ -4,6 +4,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+
 	"github.com/iotaledger/hive.go/serializer"
 )
 
@@ -11,6 +13,9 @@ var (
 	// ErrTransactionBuilderUnsupportedAddress gets returned when an unsupported address type
 	// is given for a builder operation.
 	ErrTransactionBuilderUnsupportedAddress = errors.New("unsupported address type")
+	// ErrTransactionBuilderOutputIndexOutOfRange gets returned when an output index given
+	// for a builder operation does not refer to an added output.
+	ErrTransactionBuilderOutputIndexOutOfRange = errors.New("output index out of range")
 )
 
 // NewTransactionBuilder creates a new TransactionBuilder.
@@ -21,7 +26,8 @@ func NewTransactionBuilder() *TransactionBuilder {
 			Outputs: serializer.Serializables{},
 			Payload: nil,
 		},
-		inputToAddr: map[UTXOInputID]Address{},
+		inputToAddr:       map[UTXOInputID]Address{},
+		outputAnnotations: map[int]string{},
 	}
 }
 
@@ -30,8 +36,15 @@ type TransactionBuilder struct {
 	occurredBuildErr error
 	essence          *TransactionEssence
 	inputToAddr      map[UTXOInputID]Address
+	// the annotations keyed by the insertion index of the annotated output
+	outputAnnotations map[int]string
+	// the insertion index of the output at the given position within the essence
+	outputInsertionIndices []int
 }
 
+// OutputAnnotations maps the index of an output within a built TransactionEssence to its annotation.
+type OutputAnnotations map[int]string
+
 // ToBeSignedUTXOInput defines a UTXO input which needs to be signed.
 type ToBeSignedUTXOInput struct {
 	// The address to which this input belongs to.
@@ -81,10 +94,23 @@ func (b *TransactionBuilder) AddInputsViaNodeQuery(ctx context.Context, addr Add
 
 // AddOutput adds the given output to the builder.
 func (b *TransactionBuilder) AddOutput(output Output) *TransactionBuilder {
+	b.outputInsertionIndices = append(b.outputInsertionIndices, len(b.essence.Outputs))
 	b.essence.Outputs = append(b.essence.Outputs, output)
 	return b
 }
 
+// AnnotateOutput attaches the given annotation to the output at the given index, in the order
+// the outputs were added to the builder. Annotations are not part of the built transaction and are
+// only meant to carry additional information (labels, notes etc.) for the caller.
+func (b *TransactionBuilder) AnnotateOutput(index int, annotation string) *TransactionBuilder {
+	if index < 0 || index >= len(b.essence.Outputs) {
+		b.occurredBuildErr = fmt.Errorf("%w: can't annotate output at index %d, %d outputs added", ErrTransactionBuilderOutputIndexOutOfRange, index, len(b.essence.Outputs))
+		return b
+	}
+	b.outputAnnotations[index] = annotation
+	return b
+}
+
 // AddIndexationPayload adds the given Indexation as the inner payload.
 func (b *TransactionBuilder) AddIndexationPayload(payload *Indexation) *TransactionBuilder {
 	b.essence.Payload = payload
@@ -109,13 +135,38 @@ func (b *TransactionBuilder) BuildAndSwapToMessageBuilder(signer AddressSigner,
 	return msgBuilder.Payload(tx)
 }
 
+// BuildWithAnnotations builds the transaction like Build and additionally returns the annotations of the outputs
+// keyed by the index of the annotated output within the built transaction's (lexically ordered) essence.
+func (b *TransactionBuilder) BuildWithAnnotations(signer AddressSigner) (*Transaction, OutputAnnotations, error) {
+	tx, err := b.Build(signer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotations := OutputAnnotations{}
+	for i, insertionIndex := range b.outputInsertionIndices {
+		if annotation, has := b.outputAnnotations[insertionIndex]; has {
+			annotations[i] = annotation
+		}
+	}
+
+	return tx, annotations, nil
+}
+
 // Build sings the inputs with the given signer and returns the built payload.
+// The first input of every address gets a SignatureUnlockBlock, further inputs of the same address
+// get a ReferenceUnlockBlock pointing to it. If the signer is missing the keys of an input's address,
+// the returned error names the input and its address.
 func (b *TransactionBuilder) Build(signer AddressSigner) (*Transaction, error) {
 
 	if b.occurredBuildErr != nil {
 		return nil, b.occurredBuildErr
 	}
 
+	// sort the outputs while keeping track of their insertion indices. the sort is stable, so that outputs
+	// with equal serialized forms keep their insertion order, which the sorting within SigningMessage preserves.
+	sort.Stable(outputsWithInsertionIndices{outputs: b.essence.Outputs, insertionIndices: b.outputInsertionIndices})
+
 	// sort inputs and outputs by their serialized byte order
 	txEssenceData, err := b.essence.SigningMessage()
 	if err != nil {
@@ -141,7 +192,7 @@ func (b *TransactionBuilder) Build(signer AddressSigner) (*Transaction, error) {
 		var signature serializer.Serializable
 		signature, err = signer.Sign(addr, txEssenceData)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("unable to sign input at index %d for address %s: %w", i, addrStr, err)
 		}
 
 		unlockBlocks = append(unlockBlocks, &SignatureUnlockBlock{Signature: signature})
@@ -152,3 +203,22 @@ func (b *TransactionBuilder) Build(signer AddressSigner) (*Transaction, error) {
 
 	return sigTxPayload, nil
 }
+
+// sorts outputs by their serialized byte order and applies the same swaps to their insertion indices.
+type outputsWithInsertionIndices struct {
+	outputs          serializer.Serializables
+	insertionIndices []int
+}
+
+func (o outputsWithInsertionIndices) Len() int {
+	return len(o.outputs)
+}
+
+func (o outputsWithInsertionIndices) Less(i, j int) bool {
+	return serializer.SortedSerializables(o.outputs).Less(i, j)
+}
+
+func (o outputsWithInsertionIndices) Swap(i, j int) {
+	o.outputs[i], o.outputs[j] = o.outputs[j], o.outputs[i]
+	o.insertionIndices[i], o.insertionIndices[j] = o.insertionIndices[j], o.insertionIndices[i]
+}
@@ -120,3 +120,101 @@ func TestTransactionBuilder(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionBuilder_BuildWithAnnotations(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	// output addresses are chosen so that lexical ordering reverses the order in which they were added
+	outputAddr1 := &iotago.Ed25519Address{0xff}
+	outputAddr2 := &iotago.Ed25519Address{0x0f}
+	outputAddr3 := &iotago.Ed25519Address{0x00}
+
+	tx, annotations, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 10}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 20}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr3, Amount: 30}).
+		AnnotateOutput(0, "rent").
+		AnnotateOutput(2, "savings").
+		BuildWithAnnotations(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	assert.Equal(t, iotago.OutputAnnotations{0: "savings", 2: "rent"}, annotations)
+	outputs := tx.Essence.(*iotago.TransactionEssence).Outputs
+	assert.Equal(t, outputAddr3, outputs[0].(*iotago.SigLockedSingleOutput).Address)
+	assert.Equal(t, outputAddr1, outputs[2].(*iotago.SigLockedSingleOutput).Address)
+
+	_, _, err = iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 10}).
+		AnnotateOutput(1, "missing").
+		BuildWithAnnotations(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.True(t, errors.Is(err, iotago.ErrTransactionBuilderOutputIndexOutOfRange))
+
+	// annotations follow their outputs across builds, which re-order the outputs in place
+	builder := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 10}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 20}).
+		AnnotateOutput(0, "rent")
+	_, annotations, err = builder.BuildWithAnnotations(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+	assert.Equal(t, iotago.OutputAnnotations{1: "rent"}, annotations)
+
+	_, annotations, err = builder.
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr3, Amount: 30}).
+		AnnotateOutput(1, "food").
+		AnnotateOutput(2, "savings").
+		BuildWithAnnotations(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+	assert.Equal(t, iotago.OutputAnnotations{0: "savings", 1: "food", 2: "rent"}, annotations)
+
+	// annotations are kept per added output rather than per output object, but an essence
+	// containing the same output twice deposits twice to the same address and is rejected
+	sameOutput := &iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 10}
+	_, _, err = iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(sameOutput).
+		AddOutput(sameOutput).
+		AnnotateOutput(0, "first").
+		AnnotateOutput(1, "second").
+		BuildWithAnnotations(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.True(t, errors.Is(err, iotago.ErrOutputAddrNotUnique))
+}
+
+func TestTransactionBuilder_UnlockBlocks(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr1 := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	identityTwo := tpkg.RandEd25519PrivateKey()
+	inputAddr2 := iotago.AddressFromEd25519PubKey(identityTwo.Public().(ed25519.PublicKey))
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+
+	builder := func() *iotago.TransactionBuilder {
+		return iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr1, Input: &iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{1}}}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr2, Input: &iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{2}}}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr1, Input: &iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{3}}}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+	}
+
+	tx, err := builder().Build(iotago.NewInMemoryAddressSigner(
+		iotago.AddressKeys{Address: &inputAddr1, Keys: identityOne},
+		iotago.AddressKeys{Address: &inputAddr2, Keys: identityTwo},
+	))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.SyntacticallyValidate())
+
+	assert.Len(t, tx.UnlockBlocks, 3)
+	assert.IsType(t, &iotago.SignatureUnlockBlock{}, tx.UnlockBlocks[0])
+	assert.IsType(t, &iotago.SignatureUnlockBlock{}, tx.UnlockBlocks[1])
+	assert.Equal(t, &iotago.ReferenceUnlockBlock{Reference: 0}, tx.UnlockBlocks[2])
+
+	_, err = builder().Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr1, Keys: identityOne}))
+	assert.True(t, errors.Is(err, iotago.ErrAddressKeysNotMapped))
+	assert.Contains(t, err.Error(), inputAddr2.String())
+}
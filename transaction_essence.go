@@ -1,10 +1,14 @@
 package iotago
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"math"
 	"sort"
+	"sync"
 
 	"github.com/iotaledger/hive.go/serializer"
 
@@ -29,6 +33,9 @@ const (
 	MaxOutputsCount = 127
 	// MinOutputsCount defines the minimum amount of inputs within a TransactionEssence.
 	MinOutputsCount = 1
+
+	// TransactionEssenceContentHashDomain defines the domain separation prefix used to compute the content hash of a TransactionEssence.
+	TransactionEssenceContentHashDomain = "iota.go/transaction-essence/content-hash"
 )
 
 var (
@@ -46,6 +53,10 @@ var (
 	ErrOutputDepositsMoreThanTotalSupply = errors.New("an output can not deposit more than the total supply")
 	// ErrOutputDustAllowanceLessThanMinDeposit gets returned if a SigLockedDustAllowanceOutput deposits less than OutputSigLockedDustAllowanceOutputMinDeposit.
 	ErrOutputDustAllowanceLessThanMinDeposit = errors.New("dust allowance output deposits less than the minimum required amount")
+	// ErrIndexationPayloadInvalidInEssence gets returned if the indexation payload embedded in a transaction essence is invalid.
+	ErrIndexationPayloadInvalidInEssence = errors.New("embedded indexation payload is invalid")
+	// ErrEssencePayloadNotIndexation gets returned if the payload embedded in a transaction essence is not an indexation payload.
+	ErrEssencePayloadNotIndexation = errors.New("transaction essences only allow embedded indexation payloads")
 
 	// restrictions around input within a transaction.
 	inputsArrayBound = serializer.ArrayRules{
@@ -93,16 +104,47 @@ func (u *TransactionEssence) SortInputsOutputs() {
 
 // SigningMessage returns the to be signed message.
 func (u *TransactionEssence) SigningMessage() ([]byte, error) {
-	essenceBytes, err := u.Serialize(serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering)
+	buf := signingMessageBufPool.Get().(*[]byte)
+	defer signingMessageBufPool.Put(buf)
+
+	essenceBytes, err := u.SerializeInto((*buf)[:0], serializer.DeSeriModePerformValidation|serializer.DeSeriModePerformLexicalOrdering)
 	if err != nil {
 		return nil, err
 	}
+	*buf = essenceBytes
 	essenceBytesHash := blake2b.Sum256(essenceBytes)
 	return essenceBytesHash[:], nil
 }
 
+// buffers reused by SigningMessage() to serialize the essence into.
+var signingMessageBufPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, TransactionEssenceMinByteSize+MaxInputsCount*UTXOInputSize)
+		return &buf
+	},
+}
+
+// ContentHash returns a hash over the lexically ordered serialized form of the TransactionEssence
+// which can be used as a map key to identify equivalent essences. It only differs from the hash
+// returned by SigningMessage() through domain separation. Unlike SigningMessage(), ContentHash()
+// does not re-order the inputs and outputs of the TransactionEssence in place.
+func (u *TransactionEssence) ContentHash() ([32]byte, error) {
+	sorted := &TransactionEssence{
+		Inputs:  append(serializer.Serializables{}, u.Inputs...),
+		Outputs: append(serializer.Serializables{}, u.Outputs...),
+		Payload: u.Payload,
+	}
+
+	essenceBytes, err := sorted.Serialize(serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering)
+	if err != nil {
+		return [32]byte{}, err
+	}
+
+	return blake2b.Sum256(append([]byte(TransactionEssenceContentHashDomain), essenceBytes...)), nil
+}
+
 func (u *TransactionEssence) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(TransactionEssenceMinByteSize, len(data)); err != nil {
@@ -176,6 +218,7 @@ func (u *TransactionEssence) Deserialize(data []byte, deSeriMode serializer.DeSe
 			return nil
 		}).
 		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (u *TransactionEssence) Serialize(deSeriMode serializer.DeSerializationMode) (data []byte, err error) {
@@ -236,6 +279,111 @@ func (u *TransactionEssence) Serialize(deSeriMode serializer.DeSerializationMode
 		Serialize()
 }
 
+// SerializeInto appends the serialized form of the TransactionEssence to dst and returns the extended slice.
+// The appended bytes and the applied validation equal those of Serialize(). The inputs, outputs and payload
+// are encoded directly into dst, hence no allocation happens under DeSeriModeNoValidation if dst has enough capacity.
+// On error, dst is returned unchanged.
+func (u *TransactionEssence) SerializeInto(dst []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) && u.Payload != nil {
+		if _, isIndexationPayload := u.Payload.(*Indexation); !isIndexationPayload {
+			return dst, fmt.Errorf("%w: transaction essences only allow embedded indexation payloads but got %T instead", serializer.ErrInvalidBytes, u.Payload)
+		}
+	}
+
+	if deSeriMode.HasMode(serializer.DeSeriModePerformLexicalOrdering) {
+		u.SortInputsOutputs()
+	}
+
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+		if err := u.SyntacticallyValidate(); err != nil {
+			return dst, err
+		}
+	}
+
+	buf := append(dst, TransactionEssenceNormal)
+
+	buf, err := appendSerializables(buf, u.Inputs, deSeriMode, &inputsArrayBound, "inputs")
+	if err != nil {
+		return dst, fmt.Errorf("unable to serialize transaction essence inputs: %w", err)
+	}
+
+	buf, err = appendSerializables(buf, u.Outputs, deSeriMode, &outputsArrayBound, "outputs")
+	if err != nil {
+		return dst, fmt.Errorf("unable to serialize transaction essence outputs: %w", err)
+	}
+
+	if u.Payload == nil {
+		return appendUint32(buf, 0), nil
+	}
+
+	// the payload length is filled in once the payload is written
+	payloadLengthOffset := len(buf)
+	buf = appendUint32(buf, 0)
+	if buf, err = appendSerializable(buf, u.Payload, deSeriMode); err != nil {
+		return dst, fmt.Errorf("unable to serialize transaction essence's embedded output: unable to serialize payload: %w", err)
+	}
+	binary.LittleEndian.PutUint32(buf[payloadLengthOffset:], uint32(len(buf)-payloadLengthOffset-serializer.PayloadLengthByteSize))
+
+	return buf, nil
+}
+
+// implemented by objects which can append their serialized form to a buffer without allocating.
+type serializedAppender interface {
+	appendSerialized(dst []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error)
+}
+
+// appends the serialized form of the given object to dst.
+func appendSerializable(dst []byte, seri serializer.Serializable, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if appender, ok := seri.(serializedAppender); ok {
+		return appender.appendSerialized(dst, deSeriMode)
+	}
+	data, err := seri.Serialize(deSeriMode)
+	if err != nil {
+		return dst, err
+	}
+	return append(dst, data...), nil
+}
+
+// appends the uint16 length prefixed serialized form of the given objects to dst
+// and validates their lexical order according to the given ArrayRules.
+func appendSerializables(dst []byte, seris serializer.Serializables, deSeriMode serializer.DeSerializationMode, arrayRules *serializer.ArrayRules, name string) ([]byte, error) {
+	if len(seris) > math.MaxUint16 {
+		return dst, fmt.Errorf("unable to serialize slice length: length %d is out of range (0-%d)", len(seris), math.MaxUint16)
+	}
+	dst = appendUint16(dst, uint16(len(seris)))
+
+	var lexicalOrderValidator serializer.ElementValidationFunc
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) && arrayRules.ValidationMode.HasMode(serializer.ArrayValidationModeLexicalOrdering) {
+		lexicalOrderValidator = arrayRules.LexicalOrderValidator()
+	}
+
+	for i, seri := range seris {
+		start := len(dst)
+		var err error
+		if dst, err = appendSerializable(dst, seri, deSeriMode); err != nil {
+			return dst, err
+		}
+		if lexicalOrderValidator != nil {
+			if err := lexicalOrderValidator(i, dst[start:]); err != nil {
+				return dst, fmt.Errorf("%w: unable to serialize %s of transaction essence since %s are not in lexical order", err, name, name)
+			}
+		}
+	}
+	return dst, nil
+}
+
+func appendUint16(dst []byte, v uint16) []byte {
+	return append(dst, byte(v), byte(v>>8))
+}
+
+func appendUint32(dst []byte, v uint32) []byte {
+	return append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
+
+func appendUint64(dst []byte, v uint64) []byte {
+	return append(dst, byte(v), byte(v>>8), byte(v>>16), byte(v>>24), byte(v>>32), byte(v>>40), byte(v>>48), byte(v>>56))
+}
+
 func (u *TransactionEssence) MarshalJSON() ([]byte, error) {
 	jTransactionEssence := &jsonTransactionEssence{
 		Inputs:  make([]*json.RawMessage, len(u.Inputs)),
@@ -273,6 +421,13 @@ func (u *TransactionEssence) MarshalJSON() ([]byte, error) {
 	return json.Marshal(jTransactionEssence)
 }
 
+// MarshalJSONCanonical returns the JSON representation of the TransactionEssence in a byte-stable form:
+// object keys are sorted and no insignificant whitespace is emitted. Inputs and outputs keep their order,
+// as it is significant. The result can be decoded via UnmarshalJSON.
+func (u *TransactionEssence) MarshalJSONCanonical() ([]byte, error) {
+	return canonicalJSON(u)
+}
+
 func (u *TransactionEssence) UnmarshalJSON(bytes []byte) error {
 	jTransactionEssence := &jsonTransactionEssence{}
 	if err := json.Unmarshal(bytes, jTransactionEssence); err != nil {
@@ -286,6 +441,361 @@ func (u *TransactionEssence) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// Size returns the size of the TransactionEssence in its serialized form without serializing it.
+// Objects of types which can't tell their size are serialized to determine it: Size panics if that fails,
+// as the size of a TransactionEssence which can not be serialized is undefined.
+func (u *TransactionEssence) Size() int {
+	// the essence type is denoted by a single byte, unlike what TransactionEssenceMinByteSize assumes
+	size := serializer.SmallTypeDenotationByteSize + serializer.UInt16ByteSize + serializer.UInt16ByteSize + serializer.PayloadLengthByteSize
+	for _, input := range u.Inputs {
+		size += mustSerializedSize(input)
+	}
+	for _, output := range u.Outputs {
+		size += mustSerializedSize(output)
+	}
+	return size + mustSerializedSize(u.Payload)
+}
+
+// RunningDepositSum returns the sum of the deposits of the TransactionEssence's outputs.
+// It returns ErrOutputsSumExceedsTotalSupply as soon as the accumulated deposit exceeds the total supply,
+// so that a caller adding outputs one by one can check the essence after each addition.
+func (u *TransactionEssence) RunningDepositSum() (uint64, error) {
+	var sum uint64
+	for i, output := range u.Outputs {
+		out, ok := output.(Output)
+		if !ok {
+			return 0, fmt.Errorf("%w: output %d is of type %T", ErrUnknownOutputType, i, output)
+		}
+		deposit, err := out.Deposit()
+		if err != nil {
+			return 0, fmt.Errorf("unable to get deposit of output %d: %w", i, err)
+		}
+		// checking against the remaining supply also rules out an overflow of sum
+		if deposit > TokenSupply-sum {
+			return 0, fmt.Errorf("%w: output %d", ErrOutputsSumExceedsTotalSupply, i)
+		}
+		sum += deposit
+	}
+	return sum, nil
+}
+
+// returns the size of the given object in its serialized form. nil objects, including nil pointers
+// of the types of this package, have a size of 0. Objects which can't tell their size are serialized
+// to determine it, in which case an error is returned if the serialization fails.
+func serializedSize(seri serializer.Serializable) (int, error) {
+	if isNilSerializable(seri) {
+		return 0, nil
+	}
+	if sizer, ok := seri.(interface{ Size() int }); ok {
+		return sizer.Size(), nil
+	}
+	data, err := seri.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return 0, fmt.Errorf("unable to determine the serialized size of %T: %w", seri, err)
+	}
+	return len(data), nil
+}
+
+// like serializedSize but panics if the size can not be determined, for use within the Size() methods.
+func mustSerializedSize(seri serializer.Serializable) int {
+	size, err := serializedSize(seri)
+	if err != nil {
+		panic(err)
+	}
+	return size
+}
+
+// tells whether the given object is nil or a nil pointer of one of the types of this package
+// which can be held by a Transaction.
+func isNilSerializable(seri serializer.Serializable) bool {
+	switch s := seri.(type) {
+	case nil:
+		return true
+	case *TransactionEssence:
+		return s == nil
+	case *UTXOInput:
+		return s == nil
+	case *SigLockedSingleOutput:
+		return s == nil
+	case *SigLockedDustAllowanceOutput:
+		return s == nil
+	case *Ed25519Address:
+		return s == nil
+	case *Indexation:
+		return s == nil
+	case *SignatureUnlockBlock:
+		return s == nil
+	case *ReferenceUnlockBlock:
+		return s == nil
+	case *Ed25519Signature:
+		return s == nil
+	}
+	return false
+}
+
+// Equal tells whether the TransactionEssence and other hold equal inputs, outputs and payload in the same order.
+func (u *TransactionEssence) Equal(other *TransactionEssence) bool {
+	if u == nil || other == nil {
+		return u == other
+	}
+
+	if len(u.Inputs) != len(other.Inputs) || len(u.Outputs) != len(other.Outputs) {
+		return false
+	}
+
+	for i := range u.Inputs {
+		if !serializablesEqual(u.Inputs[i], other.Inputs[i]) {
+			return false
+		}
+	}
+
+	for i := range u.Outputs {
+		if !serializablesEqual(u.Outputs[i], other.Outputs[i]) {
+			return false
+		}
+	}
+
+	return serializablesEqual(u.Payload, other.Payload)
+}
+
+// tells whether the given objects are equal. Objects of types without a structural comparison
+// are compared through their serialized form.
+func serializablesEqual(a serializer.Serializable, b serializer.Serializable) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	switch x := a.(type) {
+	case *UTXOInput:
+		y, ok := b.(*UTXOInput)
+		return ok && *x == *y
+	case *SigLockedSingleOutput:
+		y, ok := b.(*SigLockedSingleOutput)
+		return ok && x.Amount == y.Amount && serializablesEqual(x.Address, y.Address)
+	case *SigLockedDustAllowanceOutput:
+		y, ok := b.(*SigLockedDustAllowanceOutput)
+		return ok && x.Amount == y.Amount && serializablesEqual(x.Address, y.Address)
+	case *Ed25519Address:
+		y, ok := b.(*Ed25519Address)
+		return ok && *x == *y
+	case *Indexation:
+		y, ok := b.(*Indexation)
+		return ok && bytes.Equal(x.Index, y.Index) && bytes.Equal(x.Data, y.Data)
+	case *SignatureUnlockBlock:
+		y, ok := b.(*SignatureUnlockBlock)
+		return ok && serializablesEqual(x.Signature, y.Signature)
+	case *ReferenceUnlockBlock:
+		y, ok := b.(*ReferenceUnlockBlock)
+		return ok && *x == *y
+	case *Ed25519Signature:
+		y, ok := b.(*Ed25519Signature)
+		return ok && *x == *y
+	}
+
+	aBytes, err := a.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return false
+	}
+	bBytes, err := b.Serialize(serializer.DeSeriModeNoValidation)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aBytes, bBytes)
+}
+
+// Clone returns a deep copy of the TransactionEssence.
+// An error is returned if the TransactionEssence holds inputs, outputs or a payload of unsupported types.
+func (u *TransactionEssence) Clone() (*TransactionEssence, error) {
+	cpy := &TransactionEssence{}
+
+	if u.Inputs != nil {
+		cpy.Inputs = make(serializer.Serializables, len(u.Inputs))
+		for i, input := range u.Inputs {
+			switch in := input.(type) {
+			case *UTXOInput:
+				inCpy := *in
+				cpy.Inputs[i] = &inCpy
+			default:
+				return nil, fmt.Errorf("%w: unable to clone input of type %T at index %d", ErrUnknownInputType, input, i)
+			}
+		}
+	}
+
+	if u.Outputs != nil {
+		cpy.Outputs = make(serializer.Serializables, len(u.Outputs))
+		for i, output := range u.Outputs {
+			switch out := output.(type) {
+			case *SigLockedSingleOutput:
+				addrCpy, err := cloneAddress(out.Address)
+				if err != nil {
+					return nil, fmt.Errorf("unable to clone output at index %d: %w", i, err)
+				}
+				cpy.Outputs[i] = &SigLockedSingleOutput{Address: addrCpy, Amount: out.Amount}
+			case *SigLockedDustAllowanceOutput:
+				addrCpy, err := cloneAddress(out.Address)
+				if err != nil {
+					return nil, fmt.Errorf("unable to clone output at index %d: %w", i, err)
+				}
+				cpy.Outputs[i] = &SigLockedDustAllowanceOutput{Address: addrCpy, Amount: out.Amount}
+			default:
+				return nil, fmt.Errorf("%w: unable to clone output of type %T at index %d", ErrUnknownOutputType, output, i)
+			}
+		}
+	}
+
+	switch payload := u.Payload.(type) {
+	case nil:
+	case *Indexation:
+		cpy.Payload = &Indexation{Index: cloneBytes(payload.Index), Data: cloneBytes(payload.Data)}
+	default:
+		return nil, fmt.Errorf("%w: unable to clone payload of type %T", ErrUnsupportedPayloadType, u.Payload)
+	}
+
+	return cpy, nil
+}
+
+// WithoutPayload returns a shallow copy of the TransactionEssence without its embedded payload.
+// The copy shares its inputs and outputs with the TransactionEssence.
+// Since the payload is part of the serialized essence, the copy has a different SigningMessage()
+// than the TransactionEssence if the latter holds a payload, and so has any Transaction built from it.
+func (u *TransactionEssence) WithoutPayload() *TransactionEssence {
+	cpy := *u
+	cpy.Payload = nil
+	return &cpy
+}
+
+// AttachPayload sets the embedded payload of the TransactionEssence to the given Indexation.
+// Passing nil removes the embedded payload.
+// Since the payload is part of the serialized essence, attaching a payload changes the SigningMessage()
+// of the TransactionEssence and the ID of any Transaction holding it: signatures must be created after attaching it.
+func (u *TransactionEssence) AttachPayload(payload *Indexation) {
+	if payload == nil {
+		u.Payload = nil
+		return
+	}
+	u.Payload = payload
+}
+
+// returns a copy of the given address.
+func cloneAddress(addr serializer.Serializable) (serializer.Serializable, error) {
+	switch a := addr.(type) {
+	case nil:
+		return nil, nil
+	case *Ed25519Address:
+		cpy := *a
+		return &cpy, nil
+	default:
+		return nil, fmt.Errorf("%w: unable to clone address of type %T", ErrUnknownAddrType, addr)
+	}
+}
+
+// returns a copy of the given slice which is nil if the given slice is nil.
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+	return append([]byte{}, b...)
+}
+
+// MergeEquivalentOutputs merges outputs of the same type which deposit to the same address into a single output
+// holding the summed up deposit. The merged output takes the place of the first of its equivalent outputs.
+// Merged outputs are newly allocated, the outputs previously held by the TransactionEssence are not modified.
+// This allows a TransactionEssence to pass the OutputsAddrUniqueValidator check if its outputs would otherwise
+// deposit more than once to the same address.
+func (u *TransactionEssence) MergeEquivalentOutputs() error {
+	merged := make(serializer.Serializables, 0, len(u.Outputs))
+	seen := make(map[OutputType]map[string]int)
+
+	for i, output := range u.Outputs {
+		var addr serializer.Serializable
+		var amount uint64
+		switch out := output.(type) {
+		case *SigLockedSingleOutput:
+			addr, amount = out.Address, out.Amount
+		case *SigLockedDustAllowanceOutput:
+			addr, amount = out.Address, out.Amount
+		default:
+			return fmt.Errorf("%w: unable to merge output of type %T at index %d", ErrUnknownOutputType, output, i)
+		}
+
+		address, ok := addr.(Address)
+		if !ok {
+			return fmt.Errorf("%w: output at index %d does not deposit to an address", ErrUnknownAddrType, i)
+		}
+
+		outputType := output.(Output).Type()
+		m, ok := seen[outputType]
+		if !ok {
+			m = make(map[string]int)
+			seen[outputType] = m
+		}
+
+		mergedIndex, has := m[address.String()]
+		if !has {
+			m[address.String()] = len(merged)
+			merged = append(merged, output)
+			continue
+		}
+
+		mergedAmount, err := merged[mergedIndex].(Output).Deposit()
+		if err != nil {
+			return err
+		}
+		// merged amounts beyond the token supply are rejected before they could overflow
+		if mergedAmount > TokenSupply || amount > TokenSupply-mergedAmount {
+			return fmt.Errorf("%w: merging output at index %d", ErrOutputsSumExceedsTotalSupply, i)
+		}
+
+		switch target := merged[mergedIndex].(type) {
+		case *SigLockedSingleOutput:
+			merged[mergedIndex] = &SigLockedSingleOutput{Address: target.Address, Amount: target.Amount + amount}
+		case *SigLockedDustAllowanceOutput:
+			merged[mergedIndex] = &SigLockedDustAllowanceOutput{Address: target.Address, Amount: target.Amount + amount}
+		}
+	}
+
+	u.Outputs = merged
+	return nil
+}
+
+// EssenceDedupCache remembers the content hashes of transaction essences in order to detect
+// different transactions sharing an equivalent essence, e.g. within a mempool.
+// The cache grows with every new essence, it is up to the caller to Reset it.
+// An EssenceDedupCache is safe for concurrent use.
+type EssenceDedupCache struct {
+	mu   sync.Mutex
+	seen map[[32]byte]struct{}
+}
+
+// NewEssenceDedupCache creates a new EssenceDedupCache.
+func NewEssenceDedupCache() *EssenceDedupCache {
+	return &EssenceDedupCache{seen: make(map[[32]byte]struct{})}
+}
+
+// SeenEssence tells whether an essence equivalent to the given one, as determined by TransactionEssence.ContentHash(),
+// was already passed to SeenEssence before. The given essence is remembered for subsequent calls.
+func (c *EssenceDedupCache) SeenEssence(essence *TransactionEssence) (bool, error) {
+	contentHash, err := essence.ContentHash()
+	if err != nil {
+		return false, fmt.Errorf("unable to compute content hash of transaction essence: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, seen := c.seen[contentHash]; seen {
+		return true, nil
+	}
+	c.seen[contentHash] = struct{}{}
+	return false, nil
+}
+
+// Reset forgets all essences seen so far.
+func (c *EssenceDedupCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.seen = make(map[[32]byte]struct{})
+}
+
 // SyntacticallyValidate checks whether the transaction essence is syntactically valid by checking whether:
 //	1. every input references a unique UTXO and has valid UTXO index bounds
 //	2. every output (per type) deposits to a unique address and deposits more than zero
@@ -316,9 +826,53 @@ func (u *TransactionEssence) SyntacticallyValidate() error {
 		return err
 	}
 
+	switch payload := u.Payload.(type) {
+	case nil:
+	case *Indexation:
+		if err := syntacticallyValidateEmbeddedIndexation(payload); err != nil {
+			return &embeddedIndexationError{err: err}
+		}
+	case *Transaction:
+		return fmt.Errorf("%w: got a nested transaction", ErrEssencePayloadNotIndexation)
+	default:
+		// guards against payloads which merely wrap or mimic an indexation payload
+		return fmt.Errorf("%w: got %T", ErrEssencePayloadNotIndexation, u.Payload)
+	}
+
+	return nil
+}
+
+// checks the bounds of the index and data of an indexation payload embedded in a transaction essence.
+func syntacticallyValidateEmbeddedIndexation(indexation *Indexation) error {
+	switch {
+	case len(indexation.Index) < IndexationIndexMinLength:
+		return ErrIndexationIndexUnderMinSize
+	case len(indexation.Index) > IndexationIndexMaxLength:
+		return ErrIndexationIndexExceedsMaxSize
+	case indexation.Size() > MessageBinSerializedMaxSize:
+		return fmt.Errorf("%w: indexation data of %d bytes exceeds the max message size of %d bytes", ErrMessageExceedsMaxSize, len(indexation.Data), MessageBinSerializedMaxSize)
+	}
 	return nil
 }
 
+// wraps the reason why an indexation payload embedded in a transaction essence is invalid,
+// so that both the reason and ErrIndexationPayloadInvalidInEssence can be matched via errors.Is.
+type embeddedIndexationError struct {
+	err error
+}
+
+func (e *embeddedIndexationError) Error() string {
+	return fmt.Sprintf("%s: %s", ErrIndexationPayloadInvalidInEssence, e.err)
+}
+
+func (e *embeddedIndexationError) Unwrap() error {
+	return e.err
+}
+
+func (e *embeddedIndexationError) Is(target error) bool {
+	return target == ErrIndexationPayloadInvalidInEssence
+}
+
 // jsonTransactionEssenceSelector selects the json transaction essence object for the given type.
 func jsonTransactionEssenceSelector(ty int) (JSONSerializable, error) {
 	var obj JSONSerializable
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
+	"math"
 	"testing"
 
 	"github.com/iotaledger/iota.go/v2"
@@ -64,3 +65,332 @@ func TestTransactionEssence_Serialize(t *testing.T) {
 		})
 	}
 }
+
+func TestTransactionEssence_ContentHash(t *testing.T) {
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	outputAddr2, _ := tpkg.RandEd25519Address()
+	input1, _ := tpkg.RandUTXOInput()
+	input2, _ := tpkg.RandUTXOInput()
+	output1 := &iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 20}
+	output2 := &iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 30}
+
+	essence := &iotago.TransactionEssence{
+		Inputs:  serializer.Serializables{input1, input2},
+		Outputs: serializer.Serializables{output1, output2},
+	}
+	reordered := &iotago.TransactionEssence{
+		Inputs:  serializer.Serializables{input2, input1},
+		Outputs: serializer.Serializables{output2, output1},
+	}
+
+	hash, err := essence.ContentHash()
+	assert.NoError(t, err)
+	reorderedHash, err := reordered.ContentHash()
+	assert.NoError(t, err)
+	assert.Equal(t, hash, reorderedHash)
+
+	// the essence itself is not re-ordered
+	assert.Equal(t, output2, reordered.Outputs[0])
+
+	signingMsg, err := essence.SigningMessage()
+	assert.NoError(t, err)
+	assert.NotEqual(t, signingMsg, hash[:])
+
+	modified := &iotago.TransactionEssence{
+		Inputs:  serializer.Serializables{input1, input2},
+		Outputs: serializer.Serializables{output1, &iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 31}},
+	}
+	modifiedHash, err := modified.ContentHash()
+	assert.NoError(t, err)
+	assert.NotEqual(t, hash, modifiedHash)
+}
+
+func TestTransactionEssence_MergeEquivalentOutputs(t *testing.T) {
+	addr1, _ := tpkg.RandEd25519Address()
+	addr2, _ := tpkg.RandEd25519Address()
+	input, _ := tpkg.RandUTXOInput()
+
+	firstOutput := &iotago.SigLockedSingleOutput{Address: addr1, Amount: 100}
+	essence := &iotago.TransactionEssence{
+		Inputs: serializer.Serializables{input},
+		Outputs: serializer.Serializables{
+			firstOutput,
+			&iotago.SigLockedSingleOutput{Address: addr2, Amount: 200},
+			&iotago.SigLockedDustAllowanceOutput{Address: addr1, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			&iotago.SigLockedSingleOutput{Address: addr1, Amount: 300},
+			&iotago.SigLockedDustAllowanceOutput{Address: addr1, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+		},
+	}
+	assert.True(t, errors.Is(essence.SyntacticallyValidate(), iotago.ErrOutputAddrNotUnique))
+
+	assert.NoError(t, essence.MergeEquivalentOutputs())
+	assert.NoError(t, essence.SyntacticallyValidate())
+
+	assert.Equal(t, serializer.Serializables{
+		&iotago.SigLockedSingleOutput{Address: addr1, Amount: 400},
+		&iotago.SigLockedSingleOutput{Address: addr2, Amount: 200},
+		&iotago.SigLockedDustAllowanceOutput{Address: addr1, Amount: 2 * iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+	}, essence.Outputs)
+
+	// the original outputs are left untouched
+	assert.EqualValues(t, 100, firstOutput.Amount)
+
+	// merged amounts must neither exceed the token supply nor overflow
+	overflowing := serializer.Serializables{
+		&iotago.SigLockedSingleOutput{Address: addr1, Amount: iotago.TokenSupply},
+		&iotago.SigLockedSingleOutput{Address: addr1, Amount: math.MaxUint64 - iotago.TokenSupply + 1},
+	}
+	essence.Outputs = overflowing
+	assert.True(t, errors.Is(essence.MergeEquivalentOutputs(), iotago.ErrOutputsSumExceedsTotalSupply))
+	assert.Equal(t, overflowing, essence.Outputs)
+}
+
+func TestEssenceDedupCache(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+
+	// same essence, different signatures
+	replayedTx, err := tx.Clone()
+	assert.NoError(t, err)
+	for i := range replayedTx.UnlockBlocks {
+		replayedTx.UnlockBlocks[i], _ = tpkg.RandEd25519SignatureUnlockBlock()
+	}
+	assert.False(t, tx.Equal(replayedTx))
+
+	otherTx, _ := tpkg.RandTransaction()
+
+	cache := iotago.NewEssenceDedupCache()
+
+	seen, err := cache.SeenEssence(tx.Essence.(*iotago.TransactionEssence))
+	assert.NoError(t, err)
+	assert.False(t, seen)
+
+	seen, err = cache.SeenEssence(otherTx.Essence.(*iotago.TransactionEssence))
+	assert.NoError(t, err)
+	assert.False(t, seen)
+
+	seen, err = cache.SeenEssence(replayedTx.Essence.(*iotago.TransactionEssence))
+	assert.NoError(t, err)
+	assert.True(t, seen)
+
+	cache.Reset()
+	seen, err = cache.SeenEssence(replayedTx.Essence.(*iotago.TransactionEssence))
+	assert.NoError(t, err)
+	assert.False(t, seen)
+}
+
+func TestTransactionEssence_SyntacticallyValidateIndexation(t *testing.T) {
+	tests := []struct {
+		name   string
+		index  []byte
+		data   []byte
+		reason error
+	}{
+		{name: "ok - index at min length", index: tpkg.RandBytes(iotago.IndexationIndexMinLength)},
+		{name: "ok - index at max length", index: tpkg.RandBytes(iotago.IndexationIndexMaxLength), data: tpkg.RandBytes(100)},
+		{name: "err - empty index", index: []byte{}, reason: iotago.ErrIndexationIndexUnderMinSize},
+		{name: "err - index one byte over max length", index: tpkg.RandBytes(iotago.IndexationIndexMaxLength + 1), reason: iotago.ErrIndexationIndexExceedsMaxSize},
+		{name: "err - data exceeds max message size", index: []byte("index"), data: make([]byte, iotago.MessageBinSerializedMaxSize), reason: iotago.ErrMessageExceedsMaxSize},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			essence, _ := tpkg.RandTransactionEssence()
+			essence.Payload = &iotago.Indexation{Index: test.index, Data: test.data}
+			err := essence.SyntacticallyValidate()
+			if test.reason != nil {
+				assert.True(t, errors.Is(err, iotago.ErrIndexationPayloadInvalidInEssence))
+				assert.True(t, errors.Is(err, test.reason))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTransactionEssence_SerializeInto(t *testing.T) {
+	buf := make([]byte, 0, 1024)
+	for i := 0; i < 10; i++ {
+		essence, _ := tpkg.RandTransactionEssence()
+		if i%2 == 0 {
+			essence.Payload = &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+		}
+
+		for _, deSeriMode := range []serializer.DeSerializationMode{
+			serializer.DeSeriModeNoValidation,
+			serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering,
+		} {
+			expected, err := essence.Serialize(deSeriMode)
+			assert.NoError(t, err)
+
+			prefix := []byte{1, 2, 3}
+			data, err := essence.SerializeInto(append(buf[:0], prefix...), deSeriMode)
+			assert.NoError(t, err)
+			assert.Equal(t, prefix, data[:len(prefix)])
+			assert.Equal(t, expected, data[len(prefix):])
+			buf = data
+		}
+	}
+
+	// every input, output and address type which can be held by an essence
+	addr, _ := tpkg.RandEd25519Address()
+	utxoInput, _ := tpkg.RandUTXOInput()
+	typeTests := []struct {
+		name    string
+		input   serializer.Serializable
+		output  serializer.Serializable
+		payload serializer.Serializable
+	}{
+		{
+			name:   "UTXO input, signature locked single output with Ed25519 address",
+			input:  utxoInput,
+			output: &iotago.SigLockedSingleOutput{Address: addr, Amount: 1337},
+		},
+		{
+			name:    "UTXO input, signature locked dust allowance output with Ed25519 address, indexation",
+			input:   utxoInput,
+			output:  &iotago.SigLockedDustAllowanceOutput{Address: addr, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			payload: &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)},
+		},
+	}
+	for _, tt := range typeTests {
+		t.Run(tt.name, func(t *testing.T) {
+			essence := &iotago.TransactionEssence{
+				Inputs:  serializer.Serializables{tt.input},
+				Outputs: serializer.Serializables{tt.output},
+				Payload: tt.payload,
+			}
+			for _, deSeriMode := range []serializer.DeSerializationMode{
+				serializer.DeSeriModeNoValidation,
+				serializer.DeSeriModePerformValidation | serializer.DeSeriModePerformLexicalOrdering,
+			} {
+				expected, err := essence.Serialize(deSeriMode)
+				assert.NoError(t, err)
+				data, err := essence.SerializeInto(nil, deSeriMode)
+				assert.NoError(t, err)
+				assert.Equal(t, expected, data)
+			}
+		})
+	}
+
+	// the lexical order is validated just like in Serialize
+	essence, _ := tpkg.RandTransactionEssence()
+	zeroUTXOInput, _ := tpkg.RandUTXOInput()
+	zeroUTXOInput.TransactionID = [iotago.TransactionIDLength]byte{}
+	essence.Inputs = append(essence.Inputs, zeroUTXOInput)
+	_, err := essence.Serialize(serializer.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, serializer.ErrArrayValidationOrderViolatesLexicalOrder))
+	prefix := []byte{1, 2, 3}
+	data, err := essence.SerializeInto(prefix, serializer.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, serializer.ErrArrayValidationOrderViolatesLexicalOrder))
+	// dst is returned unchanged on error
+	assert.Equal(t, prefix, data)
+
+	// a reused buffer is written to without allocating
+	essence, _ = tpkg.RandTransactionEssence()
+	essence.Payload = &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+	buf, err = essence.SerializeInto(buf[:0], serializer.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	allocs := testing.AllocsPerRun(100, func() {
+		buf, _ = essence.SerializeInto(buf[:0], serializer.DeSeriModeNoValidation)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestTransactionEssence_RunningDepositSum(t *testing.T) {
+	addr, _ := tpkg.RandEd25519Address()
+	tests := []struct {
+		name    string
+		amounts []uint64
+		sum     uint64
+		err     error
+	}{
+		{name: "ok - no outputs", sum: 0},
+		{name: "ok - multiple outputs", amounts: []uint64{100, 200, 300}, sum: 600},
+		{name: "ok - total supply", amounts: []uint64{iotago.TokenSupply - 1, 1}, sum: iotago.TokenSupply},
+		{name: "err - exceeds total supply", amounts: []uint64{iotago.TokenSupply, 1}, err: iotago.ErrOutputsSumExceedsTotalSupply},
+		{name: "err - would overflow", amounts: []uint64{iotago.TokenSupply, ^uint64(0)}, err: iotago.ErrOutputsSumExceedsTotalSupply},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			essence := &iotago.TransactionEssence{}
+			for _, amount := range test.amounts {
+				essence.Outputs = append(essence.Outputs, &iotago.SigLockedSingleOutput{Address: addr, Amount: amount})
+			}
+			sum, err := essence.RunningDepositSum()
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.sum, sum)
+		})
+	}
+}
+
+func TestTransactionEssence_WithoutPayload(t *testing.T) {
+	essence, _ := tpkg.RandTransactionEssence()
+	payload := &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+	essence.AttachPayload(payload)
+	assert.Equal(t, payload, essence.Payload)
+
+	withPayloadMsg, err := essence.SigningMessage()
+	assert.NoError(t, err)
+
+	bare := essence.WithoutPayload()
+	assert.Nil(t, bare.Payload)
+	assert.Equal(t, payload, essence.Payload)
+	assert.Equal(t, essence.Inputs, bare.Inputs)
+	assert.Equal(t, essence.Outputs, bare.Outputs)
+
+	bareMsg, err := bare.SigningMessage()
+	assert.NoError(t, err)
+	assert.NotEqual(t, withPayloadMsg, bareMsg)
+
+	// re-attaching the payload restores the signing message
+	bare.AttachPayload(payload)
+	reattachedMsg, err := bare.SigningMessage()
+	assert.NoError(t, err)
+	assert.Equal(t, withPayloadMsg, reattachedMsg)
+
+	// attaching nil removes the payload instead of storing a typed nil
+	bare.AttachPayload(nil)
+	assert.True(t, bare.Payload == nil)
+}
+
+// a payload which serializes like an indexation payload but carries a transaction.
+type disguisedTransactionPayload struct {
+	*iotago.Indexation
+	Transaction *iotago.Transaction
+}
+
+func TestTransactionEssence_SyntacticallyValidatePayloadType(t *testing.T) {
+	nestedTx, _ := tpkg.RandTransaction()
+	tests := []struct {
+		name    string
+		payload serializer.Serializable
+		err     error
+	}{
+		{name: "ok - no payload"},
+		{name: "ok - indexation", payload: &iotago.Indexation{Index: []byte("index")}},
+		{name: "err - nested transaction", payload: nestedTx, err: iotago.ErrEssencePayloadNotIndexation},
+		{
+			name:    "err - transaction disguised as indexation",
+			payload: &disguisedTransactionPayload{Indexation: &iotago.Indexation{Index: []byte("index")}, Transaction: nestedTx},
+			err:     iotago.ErrEssencePayloadNotIndexation,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			essence, _ := tpkg.RandTransactionEssence()
+			essence.Payload = test.payload
+			err := essence.SyntacticallyValidate()
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
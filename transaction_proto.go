@@ -0,0 +1,241 @@
+package iotago
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/pb"
+)
+
+var (
+	// ErrInvalidProto gets returned when a Protocol Buffers message can not be converted into its object.
+	ErrInvalidProto = errors.New("invalid protobuf message")
+)
+
+// ToProto converts the Transaction into its Protocol Buffers representation.
+func (t *Transaction) ToProto() (*pb.Transaction, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction essence is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	pbEssence, err := txEssence.ToProto()
+	if err != nil {
+		return nil, err
+	}
+
+	pbTx := &pb.Transaction{
+		Essence:      pbEssence,
+		UnlockBlocks: make([]*pb.UnlockBlock, len(t.UnlockBlocks)),
+	}
+
+	for i, unlockBlock := range t.UnlockBlocks {
+		switch ub := unlockBlock.(type) {
+		case *SignatureUnlockBlock:
+			sig, ok := ub.Signature.(*Ed25519Signature)
+			if !ok {
+				return nil, fmt.Errorf("%w: signature unlock block at index %d holds unsupported signature type %T", ErrUnknownSignatureType, i, ub.Signature)
+			}
+			pbTx.UnlockBlocks[i] = &pb.UnlockBlock{
+				UnlockBlock: &pb.UnlockBlock_SignatureUnlockBlock{
+					SignatureUnlockBlock: &pb.SignatureUnlockBlock{
+						Signature: &pb.SignatureUnlockBlock_Ed25519Signature{
+							Ed25519Signature: &pb.Ed25519Signature{
+								PublicKey: append([]byte{}, sig.PublicKey[:]...),
+								Signature: append([]byte{}, sig.Signature[:]...),
+							},
+						},
+					},
+				},
+			}
+		case *ReferenceUnlockBlock:
+			pbTx.UnlockBlocks[i] = &pb.UnlockBlock{
+				UnlockBlock: &pb.UnlockBlock_ReferenceUnlockBlock{
+					ReferenceUnlockBlock: &pb.ReferenceUnlockBlock{Reference: uint32(ub.Reference)},
+				},
+			}
+		default:
+			return nil, fmt.Errorf("%w: unlock block at index %d is of unsupported type %T", ErrUnknownUnlockBlockType, i, unlockBlock)
+		}
+	}
+
+	return pbTx, nil
+}
+
+// TransactionFromProto converts the given Protocol Buffers representation of a transaction into a Transaction.
+// Like JSON decoding, this function does not syntactically validate the produced Transaction.
+func TransactionFromProto(pbTx *pb.Transaction) (*Transaction, error) {
+	if pbTx.GetEssence() == nil {
+		return nil, fmt.Errorf("%w: transaction essence is nil", ErrInvalidProto)
+	}
+
+	txEssence, err := TransactionEssenceFromProto(pbTx.GetEssence())
+	if err != nil {
+		return nil, err
+	}
+
+	unlockBlocks := make(serializer.Serializables, len(pbTx.GetUnlockBlocks()))
+	for i, pbUnlockBlock := range pbTx.GetUnlockBlocks() {
+		switch {
+		case pbUnlockBlock.GetSignatureUnlockBlock() != nil:
+			pbSig := pbUnlockBlock.GetSignatureUnlockBlock().GetEd25519Signature()
+			if pbSig == nil {
+				return nil, fmt.Errorf("%w: signature unlock block at index %d holds no Ed25519 signature", ErrInvalidProto, i)
+			}
+			if len(pbSig.GetPublicKey()) != ed25519.PublicKeySize || len(pbSig.GetSignature()) != ed25519.SignatureSize {
+				return nil, fmt.Errorf("%w: Ed25519 signature at index %d has invalid public key or signature length", ErrInvalidProto, i)
+			}
+			sig := &Ed25519Signature{}
+			copy(sig.PublicKey[:], pbSig.GetPublicKey())
+			copy(sig.Signature[:], pbSig.GetSignature())
+			unlockBlocks[i] = &SignatureUnlockBlock{Signature: sig}
+		case pbUnlockBlock.GetReferenceUnlockBlock() != nil:
+			ref := pbUnlockBlock.GetReferenceUnlockBlock().GetReference()
+			if ref > math.MaxUint16 {
+				return nil, fmt.Errorf("%w: reference unlock block at index %d references %d", ErrInvalidProto, i, ref)
+			}
+			unlockBlocks[i] = &ReferenceUnlockBlock{Reference: uint16(ref)}
+		default:
+			return nil, fmt.Errorf("%w: unlock block at index %d is of unknown type", ErrInvalidProto, i)
+		}
+	}
+
+	return &Transaction{Essence: txEssence, UnlockBlocks: unlockBlocks}, nil
+}
+
+// ToProto converts the TransactionEssence into its Protocol Buffers representation.
+func (u *TransactionEssence) ToProto() (*pb.TransactionEssence, error) {
+	pbEssence := &pb.TransactionEssence{
+		Inputs:  make([]*pb.UTXOInput, len(u.Inputs)),
+		Outputs: make([]*pb.Output, len(u.Outputs)),
+	}
+
+	for i, input := range u.Inputs {
+		utxoInput, ok := input.(*UTXOInput)
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported input type %T at index %d", ErrUnknownInputType, input, i)
+		}
+		pbEssence.Inputs[i] = &pb.UTXOInput{
+			TransactionID:          append([]byte{}, utxoInput.TransactionID[:]...),
+			TransactionOutputIndex: uint32(utxoInput.TransactionOutputIndex),
+		}
+	}
+
+	for i, output := range u.Outputs {
+		switch out := output.(type) {
+		case *SigLockedSingleOutput:
+			pbAddr, err := addressToProto(out.Address)
+			if err != nil {
+				return nil, fmt.Errorf("output at index %d: %w", i, err)
+			}
+			pbEssence.Outputs[i] = &pb.Output{
+				Output: &pb.Output_SigLockedSingleOutput{
+					SigLockedSingleOutput: &pb.SigLockedSingleOutput{Address: pbAddr, Amount: out.Amount},
+				},
+			}
+		case *SigLockedDustAllowanceOutput:
+			pbAddr, err := addressToProto(out.Address)
+			if err != nil {
+				return nil, fmt.Errorf("output at index %d: %w", i, err)
+			}
+			pbEssence.Outputs[i] = &pb.Output{
+				Output: &pb.Output_SigLockedDustAllowanceOutput{
+					SigLockedDustAllowanceOutput: &pb.SigLockedDustAllowanceOutput{Address: pbAddr, Amount: out.Amount},
+				},
+			}
+		default:
+			return nil, fmt.Errorf("%w: unsupported output type %T at index %d", ErrUnknownOutputType, output, i)
+		}
+	}
+
+	switch payload := u.Payload.(type) {
+	case nil:
+	case *Indexation:
+		pbEssence.Payload = &pb.Indexation{
+			Index: append([]byte{}, payload.Index...),
+			Data:  append([]byte{}, payload.Data...),
+		}
+	default:
+		return nil, fmt.Errorf("%w: transaction essences only allow embedded indexation payloads but got %T instead", ErrUnsupportedPayloadType, u.Payload)
+	}
+
+	return pbEssence, nil
+}
+
+// TransactionEssenceFromProto converts the given Protocol Buffers representation of a transaction essence into a TransactionEssence.
+func TransactionEssenceFromProto(pbEssence *pb.TransactionEssence) (*TransactionEssence, error) {
+	txEssence := &TransactionEssence{
+		Inputs:  make(serializer.Serializables, len(pbEssence.GetInputs())),
+		Outputs: make(serializer.Serializables, len(pbEssence.GetOutputs())),
+	}
+
+	for i, pbInput := range pbEssence.GetInputs() {
+		if len(pbInput.GetTransactionID()) != TransactionIDLength {
+			return nil, fmt.Errorf("%w: input at index %d has a transaction ID of length %d", ErrInvalidProto, i, len(pbInput.GetTransactionID()))
+		}
+		if pbInput.GetTransactionOutputIndex() > math.MaxUint16 {
+			return nil, fmt.Errorf("%w: input at index %d references output index %d", ErrInvalidProto, i, pbInput.GetTransactionOutputIndex())
+		}
+		utxoInput := &UTXOInput{TransactionOutputIndex: uint16(pbInput.GetTransactionOutputIndex())}
+		copy(utxoInput.TransactionID[:], pbInput.GetTransactionID())
+		txEssence.Inputs[i] = utxoInput
+	}
+
+	for i, pbOutput := range pbEssence.GetOutputs() {
+		switch {
+		case pbOutput.GetSigLockedSingleOutput() != nil:
+			pbOut := pbOutput.GetSigLockedSingleOutput()
+			addr, err := addressFromProto(pbOut.GetAddress())
+			if err != nil {
+				return nil, fmt.Errorf("output at index %d: %w", i, err)
+			}
+			txEssence.Outputs[i] = &SigLockedSingleOutput{Address: addr, Amount: pbOut.GetAmount()}
+		case pbOutput.GetSigLockedDustAllowanceOutput() != nil:
+			pbOut := pbOutput.GetSigLockedDustAllowanceOutput()
+			addr, err := addressFromProto(pbOut.GetAddress())
+			if err != nil {
+				return nil, fmt.Errorf("output at index %d: %w", i, err)
+			}
+			txEssence.Outputs[i] = &SigLockedDustAllowanceOutput{Address: addr, Amount: pbOut.GetAmount()}
+		default:
+			return nil, fmt.Errorf("%w: output at index %d is of unknown type", ErrInvalidProto, i)
+		}
+	}
+
+	if pbPayload := pbEssence.GetPayload(); pbPayload != nil {
+		txEssence.Payload = &Indexation{Index: pbPayload.GetIndex(), Data: pbPayload.GetData()}
+	}
+
+	return txEssence, nil
+}
+
+// converts the given address into its Protocol Buffers representation.
+func addressToProto(addr serializer.Serializable) (*pb.Address, error) {
+	switch a := addr.(type) {
+	case *Ed25519Address:
+		return &pb.Address{
+			Address: &pb.Address_Ed25519Address{
+				Ed25519Address: &pb.Ed25519Address{Address: append([]byte{}, a[:]...)},
+			},
+		}, nil
+	default:
+		return nil, fmt.Errorf("%w: type %T", ErrUnknownAddrType, addr)
+	}
+}
+
+// converts the given Protocol Buffers representation of an address into its Address.
+func addressFromProto(pbAddr *pb.Address) (Address, error) {
+	pbEd25519Addr := pbAddr.GetEd25519Address()
+	if pbEd25519Addr == nil {
+		return nil, fmt.Errorf("%w: address is nil or of unknown type", ErrInvalidProto)
+	}
+	if len(pbEd25519Addr.GetAddress()) != Ed25519AddressBytesLength {
+		return nil, fmt.Errorf("%w: Ed25519 address has length %d", ErrInvalidProto, len(pbEd25519Addr.GetAddress()))
+	}
+	addr := &Ed25519Address{}
+	copy(addr[:], pbEd25519Addr.GetAddress())
+	return addr, nil
+}
@@ -0,0 +1,119 @@
+package iotago_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/pb"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func randProtoTestTransaction() *iotago.Transaction {
+	tx, _ := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	dustAddr, _ := tpkg.RandEd25519Address()
+	txEssence.Outputs = append(txEssence.Outputs, &iotago.SigLockedDustAllowanceOutput{Address: dustAddr, Amount: 1_000_000})
+	txEssence.Payload = &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+	tx.UnlockBlocks = append(tx.UnlockBlocks, &iotago.ReferenceUnlockBlock{Reference: 0})
+	return tx
+}
+
+func TestTransaction_ProtoRoundTrip(t *testing.T) {
+	tx := randProtoTestTransaction()
+
+	pbTx, err := tx.ToProto()
+	assert.NoError(t, err)
+
+	// go through the wire format to make sure nothing is lost on transport
+	pbTxBytes, err := proto.Marshal(pbTx)
+	assert.NoError(t, err)
+	pbTxDecoded := &pb.Transaction{}
+	assert.NoError(t, proto.Unmarshal(pbTxBytes, pbTxDecoded))
+
+	txFromProto, err := iotago.TransactionFromProto(pbTxDecoded)
+	assert.NoError(t, err)
+	assert.EqualValues(t, tx, txFromProto)
+}
+
+func TestTransaction_ProtoID(t *testing.T) {
+	tx := randProtoTestTransaction()
+	// the reference unlock block is only kept for the round trip test
+	tx.UnlockBlocks = tx.UnlockBlocks[:len(tx.UnlockBlocks)-1]
+
+	pbTx, err := tx.ToProto()
+	assert.NoError(t, err)
+
+	txFromProto, err := iotago.TransactionFromProto(pbTx)
+	assert.NoError(t, err)
+
+	txID, err := tx.ID()
+	assert.NoError(t, err)
+	txFromProtoID, err := txFromProto.ID()
+	assert.NoError(t, err)
+	assert.Equal(t, txID, txFromProtoID)
+}
+
+func TestTransactionFromProto(t *testing.T) {
+	type test struct {
+		name  string
+		pbTx  func(pbTx *pb.Transaction)
+		error error
+	}
+
+	tests := []test{
+		{
+			name:  "err - no essence",
+			pbTx:  func(pbTx *pb.Transaction) { pbTx.Essence = nil },
+			error: iotago.ErrInvalidProto,
+		},
+		{
+			name:  "err - invalid transaction ID length",
+			pbTx:  func(pbTx *pb.Transaction) { pbTx.Essence.Inputs[0].TransactionID = []byte{1, 2, 3} },
+			error: iotago.ErrInvalidProto,
+		},
+		{
+			name:  "err - output index out of range",
+			pbTx:  func(pbTx *pb.Transaction) { pbTx.Essence.Inputs[0].TransactionOutputIndex = 1 << 16 },
+			error: iotago.ErrInvalidProto,
+		},
+		{
+			name:  "err - output without type",
+			pbTx:  func(pbTx *pb.Transaction) { pbTx.Essence.Outputs[0] = &pb.Output{} },
+			error: iotago.ErrInvalidProto,
+		},
+		{
+			name: "err - output without address",
+			pbTx: func(pbTx *pb.Transaction) {
+				pbTx.Essence.Outputs[0].GetSigLockedSingleOutput().Address = nil
+			},
+			error: iotago.ErrInvalidProto,
+		},
+		{
+			name: "err - invalid signature length",
+			pbTx: func(pbTx *pb.Transaction) {
+				pbTx.UnlockBlocks[0].GetSignatureUnlockBlock().GetEd25519Signature().Signature = []byte{1}
+			},
+			error: iotago.ErrInvalidProto,
+		},
+		{
+			name:  "err - unlock block without type",
+			pbTx:  func(pbTx *pb.Transaction) { pbTx.UnlockBlocks[0] = &pb.UnlockBlock{} },
+			error: iotago.ErrInvalidProto,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tx, _ := tpkg.RandTransaction()
+			pbTx, err := tx.ToProto()
+			assert.NoError(t, err)
+			tt.pbTx(pbTx)
+
+			_, err = iotago.TransactionFromProto(pbTx)
+			assert.True(t, errors.Is(err, tt.error))
+		})
+	}
+}
@@ -0,0 +1,211 @@
+package iotago
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/iotaledger/hive.go/serializer"
+)
+
+// DeserializeTransactionFrom reads exactly the bytes of one serialized Transaction from r and deserializes it.
+// It returns the Transaction and the amount of bytes consumed from r, which is left positioned right after
+// the Transaction, so that it can be called in a loop to consume a stream of concatenated transactions.
+// If r holds no more data, io.EOF is returned as is. If r ends within a Transaction, the returned error wraps io.ErrUnexpectedEOF.
+// The bytes are read piece by piece as the length prefixes and object types of the Transaction determine them,
+// hence r should be buffered if reading from it is expensive.
+// ctx is reserved for passing deserialization parameters and is currently unused.
+func DeserializeTransactionFrom(r io.Reader, deSeriMode serializer.DeSerializationMode, ctx interface{}) (*Transaction, int, error) {
+	tr := &transactionStreamReader{r: r}
+	if err := tr.readTransaction(); err != nil {
+		if errors.Is(err, io.EOF) && len(tr.data) == 0 {
+			return nil, 0, io.EOF
+		}
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, len(tr.data), fmt.Errorf("%w: stream ended within transaction after %d bytes", io.ErrUnexpectedEOF, len(tr.data))
+		}
+		return nil, len(tr.data), err
+	}
+
+	tx := &Transaction{}
+	if _, err := tx.Deserialize(tr.data, deSeriMode); err != nil {
+		return nil, len(tr.data), err
+	}
+	return tx, len(tr.data), nil
+}
+
+// collects the bytes of a serialized Transaction read from a stream.
+type transactionStreamReader struct {
+	r    io.Reader
+	data []byte
+}
+
+// reads the next n bytes of the Transaction and returns them.
+func (tr *transactionStreamReader) read(n int) ([]byte, error) {
+	if len(tr.data)+n > MessageBinSerializedMaxSize {
+		return nil, fmt.Errorf("%w: transaction within stream exceeds %d bytes", ErrMessageExceedsMaxSize, MessageBinSerializedMaxSize)
+	}
+	start := len(tr.data)
+	tr.data = append(tr.data, make([]byte, n)...)
+	read, err := io.ReadFull(tr.r, tr.data[start:])
+	tr.data = tr.data[:start+read]
+	if err != nil {
+		return nil, err
+	}
+	return tr.data[start:], nil
+}
+
+func (tr *transactionStreamReader) readByte() (byte, error) {
+	b, err := tr.read(serializer.SmallTypeDenotationByteSize)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (tr *transactionStreamReader) readUint16() (int, error) {
+	b, err := tr.read(serializer.UInt16ByteSize)
+	if err != nil {
+		return 0, err
+	}
+	return int(binary.LittleEndian.Uint16(b)), nil
+}
+
+func (tr *transactionStreamReader) readUint32() (uint32, error) {
+	b, err := tr.read(serializer.UInt32ByteSize)
+	if err != nil {
+		return 0, err
+	}
+	return binary.LittleEndian.Uint32(b), nil
+}
+
+// reads the payload type, the essence and the unlock blocks of a Transaction.
+func (tr *transactionStreamReader) readTransaction() error {
+	payloadType, err := tr.read(serializer.TypeDenotationByteSize)
+	if err != nil {
+		return err
+	}
+	if err := serializer.CheckType(payloadType, TransactionPayloadTypeID); err != nil {
+		return fmt.Errorf("unable to deserialize transaction: %w", err)
+	}
+
+	essenceType, err := tr.readByte()
+	if err != nil {
+		return err
+	}
+	if essenceType != TransactionEssenceNormal {
+		return fmt.Errorf("%w: type %d", ErrUnknownTransactionEssenceType, essenceType)
+	}
+
+	inputsCount, err := tr.readUint16()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < inputsCount; i++ {
+		if err := tr.readInput(); err != nil {
+			return err
+		}
+	}
+
+	outputsCount, err := tr.readUint16()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < outputsCount; i++ {
+		if err := tr.readOutput(); err != nil {
+			return err
+		}
+	}
+
+	payloadLength, err := tr.readUint32()
+	if err != nil {
+		return err
+	}
+	if payloadLength > MessageBinSerializedMaxSize {
+		return fmt.Errorf("%w: embedded payload of %d bytes", ErrMessageExceedsMaxSize, payloadLength)
+	}
+	if _, err := tr.read(int(payloadLength)); err != nil {
+		return err
+	}
+
+	unlockBlocksCount, err := tr.readUint16()
+	if err != nil {
+		return err
+	}
+	for i := 0; i < unlockBlocksCount; i++ {
+		if err := tr.readUnlockBlock(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (tr *transactionStreamReader) readInput() error {
+	inputType, err := tr.readByte()
+	if err != nil {
+		return err
+	}
+	switch inputType {
+	case InputUTXO:
+		_, err = tr.read(UTXOInputSize - serializer.SmallTypeDenotationByteSize)
+		return err
+	default:
+		return fmt.Errorf("%w: type %d", ErrUnknownInputType, inputType)
+	}
+}
+
+func (tr *transactionStreamReader) readOutput() error {
+	outputType, err := tr.readByte()
+	if err != nil {
+		return err
+	}
+	switch outputType {
+	case OutputSigLockedSingleOutput, OutputSigLockedDustAllowanceOutput:
+		if err := tr.readAddress(); err != nil {
+			return err
+		}
+		_, err = tr.read(serializer.UInt64ByteSize)
+		return err
+	default:
+		return fmt.Errorf("%w: type %d", ErrUnknownOutputType, outputType)
+	}
+}
+
+func (tr *transactionStreamReader) readAddress() error {
+	addrType, err := tr.readByte()
+	if err != nil {
+		return err
+	}
+	switch addrType {
+	case AddressEd25519:
+		_, err = tr.read(Ed25519AddressSerializedBytesSize - serializer.SmallTypeDenotationByteSize)
+		return err
+	default:
+		return fmt.Errorf("%w: type %d", ErrUnknownAddrType, addrType)
+	}
+}
+
+func (tr *transactionStreamReader) readUnlockBlock() error {
+	unlockBlockType, err := tr.readByte()
+	if err != nil {
+		return err
+	}
+	switch unlockBlockType {
+	case UnlockBlockSignature:
+		sigType, err := tr.readByte()
+		if err != nil {
+			return err
+		}
+		if sigType != SignatureEd25519 {
+			return fmt.Errorf("%w: type %d", ErrUnknownSignatureType, sigType)
+		}
+		_, err = tr.read(Ed25519SignatureSerializedBytesSize - serializer.SmallTypeDenotationByteSize)
+		return err
+	case UnlockBlockReference:
+		_, err = tr.read(ReferenceUnlockBlockSize - serializer.SmallTypeDenotationByteSize)
+		return err
+	default:
+		return fmt.Errorf("%w: type %d", ErrUnknownUnlockBlockType, unlockBlockType)
+	}
+}
@@ -0,0 +1,82 @@
+package iotago_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+	"testing/iotest"
+
+	"github.com/iotaledger/hive.go/serializer"
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeserializeTransactionFrom(t *testing.T) {
+	var stream bytes.Buffer
+	var txs []*iotago.Transaction
+	for i := 0; i < 3; i++ {
+		tx, txData := tpkg.RandTransaction()
+		if i == 1 {
+			txEssence := tx.Essence.(*iotago.TransactionEssence)
+			txEssence.Payload = &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+			// references make up unlock blocks of another size
+			for j := 0; j < 40; j++ {
+				utxoInput, _ := tpkg.RandUTXOInput()
+				txEssence.Inputs = append(txEssence.Inputs, utxoInput)
+				tx.UnlockBlocks = append(tx.UnlockBlocks, &iotago.ReferenceUnlockBlock{Reference: 0})
+			}
+			var err error
+			txData, err = tx.Serialize(serializer.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+		}
+		txs = append(txs, tx)
+		stream.Write(txData)
+	}
+	streamLen := stream.Len()
+	// an unbuffered reader returning a single byte per read must not be read beyond each transaction
+	r := iotest.OneByteReader(&stream)
+
+	var consumed int
+	for _, tx := range txs {
+		txFromStream, bytesRead, err := iotago.DeserializeTransactionFrom(r, serializer.DeSeriModeNoValidation, nil)
+		assert.NoError(t, err)
+		assert.True(t, tx.Equal(txFromStream))
+		consumed += bytesRead
+		assert.Equal(t, streamLen-consumed, stream.Len())
+	}
+	assert.Equal(t, streamLen, consumed)
+
+	_, bytesRead, err := iotago.DeserializeTransactionFrom(r, serializer.DeSeriModeNoValidation, nil)
+	assert.Equal(t, io.EOF, err)
+	assert.Zero(t, bytesRead)
+}
+
+func TestDeserializeTransactionFrom_Errors(t *testing.T) {
+	_, txData := tpkg.RandTransaction()
+
+	// the stream may end anywhere within the transaction
+	for _, deSeriMode := range []serializer.DeSerializationMode{serializer.DeSeriModeNoValidation, serializer.DeSeriModePerformValidation} {
+		for truncatedLen := 1; truncatedLen < len(txData); truncatedLen++ {
+			_, bytesRead, err := iotago.DeserializeTransactionFrom(bytes.NewReader(txData[:truncatedLen]), deSeriMode, nil)
+			assert.Truef(t, errors.Is(err, io.ErrUnexpectedEOF), "truncated to %d bytes: %v", truncatedLen, err)
+			assert.Equal(t, truncatedLen, bytesRead)
+		}
+	}
+
+	wrongType := append([]byte{}, txData...)
+	binary.LittleEndian.PutUint32(wrongType, iotago.MilestonePayloadTypeID)
+	_, _, err := iotago.DeserializeTransactionFrom(bytes.NewReader(wrongType), serializer.DeSeriModePerformValidation, nil)
+	assert.True(t, errors.Is(err, serializer.ErrDeserializationTypeMismatch))
+
+	// objects of unknown types can not be read as their size is unknown
+	tx, txData := tpkg.RandTransaction()
+	firstOutputOffset := serializer.TypeDenotationByteSize + serializer.SmallTypeDenotationByteSize + serializer.UInt16ByteSize +
+		len(tx.Essence.(*iotago.TransactionEssence).Inputs)*iotago.UTXOInputSize + serializer.UInt16ByteSize
+	txData[firstOutputOffset] = 0xff
+	_, bytesRead, err := iotago.DeserializeTransactionFrom(bytes.NewReader(txData), serializer.DeSeriModeNoValidation, nil)
+	assert.True(t, errors.Is(err, iotago.ErrUnknownOutputType))
+	assert.Equal(t, firstOutputOffset+1, bytesRead)
+}
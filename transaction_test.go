@@ -1,10 +1,15 @@
 package iotago_test
 
 import (
+	"bytes"
+	"context"
+	"encoding/binary"
 	"errors"
 	"github.com/iotaledger/hive.go/serializer"
 	"github.com/iotaledger/iota.go/v2/tpkg"
+	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/iotaledger/iota.go/v2"
 	"github.com/iotaledger/iota.go/v2/ed25519"
@@ -496,3 +501,1771 @@ func TestDustAllowance(t *testing.T) {
 		})
 	}
 }
+
+func TestTxSemanticRecipientWhitelist(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	outputAddr2, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 20}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: 30}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+	}
+
+	tests := []struct {
+		name     string
+		allowed  map[string]bool
+		validErr error
+	}{
+		{
+			name:    "ok - all recipients whitelisted",
+			allowed: map[string]bool{outputAddr1.String(): true, outputAddr2.String(): true},
+		},
+		{
+			name:     "err - one recipient not whitelisted",
+			allowed:  map[string]bool{outputAddr1.String(): true},
+			validErr: iotago.ErrOutputRecipientNotWhitelisted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticRecipientWhitelist(test.allowed))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestTxSemanticPayloadTagNamespace(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	type test struct {
+		name       string
+		inputUTXOs iotago.InputToOutputMapping
+		builder    *iotago.TransactionBuilder
+		validErr   error
+	}
+
+	newTest := func(name string, payload *iotago.Indexation, validErr error) test {
+		outputAddr1, _ := tpkg.RandEd25519Address()
+		inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+		builder := iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+		if payload != nil {
+			builder.AddIndexationPayload(payload)
+		}
+
+		return test{
+			name: name,
+			inputUTXOs: iotago.InputToOutputMapping{
+				inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+			},
+			builder:  builder,
+			validErr: validErr,
+		}
+	}
+
+	tests := []test{
+		newTest("ok - no embedded payload", nil, nil),
+		newTest("ok - index within namespace", &iotago.Indexation{Index: []byte("app.transfer")}, nil),
+		newTest("err - index outside namespace", &iotago.Indexation{Index: []byte("other.transfer")}, iotago.ErrEmbeddedPayloadTagNotAllowed),
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := test.builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			assert.NoError(t, err)
+
+			semanticErr := payload.SemanticallyValidate(test.inputUTXOs, iotago.TxSemanticPayloadTagNamespace([]byte("app.")))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestTransaction_SemanticallyValidate_CanonicalRefUnlockBlock(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO3 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 10},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 20},
+		inputUTXO3.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 30},
+	}
+
+	build := func() *iotago.Transaction {
+		payload, err := iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO3}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 60}).
+			Build(iotago.NewInMemoryAddressSigner(addrKeys))
+		assert.NoError(t, err)
+		return payload
+	}
+
+	t.Run("ok - references point to the first signature unlock block", func(t *testing.T) {
+		payload := build()
+		assert.NoError(t, payload.SyntacticallyValidate())
+		assert.NoError(t, payload.SemanticallyValidate(inputUTXOs))
+	})
+
+	t.Run("err - reference points to a later signature unlock block of the same address", func(t *testing.T) {
+		payload := build()
+		sigUnlockBlock, _ := tpkg.RandEd25519SignatureUnlockBlock()
+		payload.UnlockBlocks[1] = sigUnlockBlock
+		payload.UnlockBlocks[2] = &iotago.ReferenceUnlockBlock{Reference: 1}
+
+		assert.NoError(t, payload.SyntacticallyValidate())
+		assert.True(t, errors.Is(payload.SemanticallyValidate(inputUTXOs), iotago.ErrInputSignatureUnlockBlockInvalid))
+	})
+}
+
+func TestTransaction_Summary(t *testing.T) {
+	var seed [ed25519.SeedSize]byte
+	identityOne := ed25519.NewKeyFromSeed(seed[:])
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1 := &iotago.Ed25519Address{0x11}
+	outputAddr2 := &iotago.Ed25519Address{0x22}
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{0xaa}, TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: [iotago.TransactionIDLength]byte{0xbb}, TransactionOutputIndex: 3}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 1_000}).
+		AddOutput(&iotago.SigLockedDustAllowanceOutput{Address: outputAddr2, Amount: 1_000_000}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 500}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("summary"), Data: []byte{1, 2, 3}}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_000_500},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_000},
+	}
+
+	golden, err := ioutil.ReadFile("testdata/transaction_summary.golden")
+	assert.NoError(t, err)
+	assert.Equal(t, string(golden), payload.Summary(inputUTXOs))
+}
+
+func TestTransaction_SemanticallyValidateStructure(t *testing.T) {
+	inputAddr, _ := tpkg.RandEd25519Address()
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: 50},
+	}
+
+	// unsigned transaction: no unlock blocks at all
+	unsignedTx := func(outputAmount uint64) *iotago.Transaction {
+		return &iotago.Transaction{
+			Essence: &iotago.TransactionEssence{
+				Inputs:  serializer.Serializables{inputUTXO1},
+				Outputs: serializer.Serializables{&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: outputAmount}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		tx         *iotago.Transaction
+		inputUTXOs iotago.InputToOutputMapping
+		validErr   error
+	}{
+		{
+			name:       "ok - unsigned but balanced",
+			tx:         unsignedTx(50),
+			inputUTXOs: inputUTXOs,
+		},
+		{
+			name: "ok - invalid signature is skipped",
+			tx: func() *iotago.Transaction {
+				tx := unsignedTx(50)
+				sigUnlockBlock, _ := tpkg.RandEd25519SignatureUnlockBlock()
+				tx.UnlockBlocks = serializer.Serializables{sigUnlockBlock}
+				return tx
+			}(),
+			inputUTXOs: inputUTXOs,
+		},
+		{
+			name:       "err - unbalanced",
+			tx:         unsignedTx(40),
+			inputUTXOs: inputUTXOs,
+			validErr:   iotago.ErrInputOutputSumMismatch,
+		},
+		{
+			name:       "err - missing UTXO",
+			tx:         unsignedTx(50),
+			inputUTXOs: iotago.InputToOutputMapping{},
+			validErr:   iotago.ErrMissingUTXO,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.tx.SemanticallyValidateStructure(test.inputUTXOs)
+			if test.validErr != nil {
+				assert.True(t, errors.Is(err, test.validErr))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("err - custom semantic validation functions still run", func(t *testing.T) {
+		err := unsignedTx(50).SemanticallyValidateStructure(inputUTXOs, iotago.TxSemanticRecipientWhitelist(map[string]bool{}))
+		assert.True(t, errors.Is(err, iotago.ErrOutputRecipientNotWhitelisted))
+	})
+}
+
+type testMetricsSink struct {
+	failures  []string
+	durations map[string]time.Duration
+}
+
+func (sink *testMetricsSink) RecordFailure(step string) {
+	sink.failures = append(sink.failures, step)
+}
+
+func (sink *testMetricsSink) RecordDuration(step string, d time.Duration) {
+	sink.durations[step] += d
+}
+
+func TestTxSemanticWithMetrics(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("other.transfer")}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+	}
+
+	sink := &testMetricsSink{durations: make(map[string]time.Duration)}
+	semanticErr := payload.SemanticallyValidate(inputUTXOs,
+		iotago.TxSemanticWithMetrics("whitelist", sink, iotago.TxSemanticRecipientWhitelist(map[string]bool{outputAddr1.String(): true})),
+		iotago.TxSemanticWithMetrics("namespace", sink, iotago.TxSemanticPayloadTagNamespace([]byte("app."))),
+	)
+	assert.True(t, errors.Is(semanticErr, iotago.ErrEmbeddedPayloadTagNotAllowed))
+	assert.Equal(t, []string{"namespace"}, sink.failures)
+	assert.Contains(t, sink.durations, "whitelist")
+	assert.Contains(t, sink.durations, "namespace")
+
+	t.Run("nil sink", func(t *testing.T) {
+		semanticErr := payload.SemanticallyValidate(inputUTXOs,
+			iotago.TxSemanticWithMetrics("namespace", nil, iotago.TxSemanticPayloadTagNamespace([]byte("app."))),
+		)
+		assert.True(t, errors.Is(semanticErr, iotago.ErrEmbeddedPayloadTagNotAllowed))
+	})
+}
+
+func TestTxSemanticNoEmbeddedPayload(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	tests := []struct {
+		name     string
+		payload  *iotago.Indexation
+		validErr error
+	}{
+		{name: "ok - no embedded payload"},
+		{
+			name:     "err - embedded indexation payload",
+			payload:  &iotago.Indexation{Index: []byte("index"), Data: []byte{1, 2, 3}},
+			validErr: iotago.ErrEmbeddedPayloadNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			outputAddr1, _ := tpkg.RandEd25519Address()
+			inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+			builder := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+			if test.payload != nil {
+				builder.AddIndexationPayload(test.payload)
+			}
+
+			payload, err := builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			assert.NoError(t, err)
+
+			inputUTXOs := iotago.InputToOutputMapping{
+				inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+			}
+
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticNoEmbeddedPayload())
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+type countingTransactionVisitor struct {
+	inputs, outputs, unlockBlocks int
+	stopAfter                     int
+}
+
+func (v *countingTransactionVisitor) visit() error {
+	if v.stopAfter > 0 && v.inputs+v.outputs+v.unlockBlocks == v.stopAfter {
+		return iotago.ErrStopWalk
+	}
+	return nil
+}
+
+func (v *countingTransactionVisitor) VisitInput(_ int, _ serializer.Serializable) error {
+	if err := v.visit(); err != nil {
+		return err
+	}
+	v.inputs++
+	return nil
+}
+
+func (v *countingTransactionVisitor) VisitOutput(_ int, _ iotago.Output) error {
+	if err := v.visit(); err != nil {
+		return err
+	}
+	v.outputs++
+	return nil
+}
+
+func (v *countingTransactionVisitor) VisitUnlockBlock(_ int, _ serializer.Serializable) error {
+	if err := v.visit(); err != nil {
+		return err
+	}
+	v.unlockBlocks++
+	return nil
+}
+
+func TestTransaction_Walk(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	identityTwo := tpkg.RandEd25519PrivateKey()
+	inputAddr2 := iotago.AddressFromEd25519PubKey(identityTwo.Public().(ed25519.PublicKey))
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	outputAddr2, _ := tpkg.RandEd25519Address()
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr2, Input: &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray()}}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+		AddOutput(&iotago.SigLockedDustAllowanceOutput{Address: outputAddr2, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit}).
+		AddIndexationPayload(&iotago.Indexation{Index: []byte("index")}).
+		Build(iotago.NewInMemoryAddressSigner(
+			iotago.AddressKeys{Address: &inputAddr, Keys: identityOne},
+			iotago.AddressKeys{Address: &inputAddr2, Keys: identityTwo},
+		))
+	assert.NoError(t, err)
+
+	visitor := &countingTransactionVisitor{}
+	assert.NoError(t, payload.Walk(visitor))
+	assert.Equal(t, 3, visitor.inputs)
+	assert.Equal(t, 2, visitor.outputs)
+	assert.Equal(t, 3, visitor.unlockBlocks)
+
+	stoppingVisitor := &countingTransactionVisitor{stopAfter: 4}
+	assert.NoError(t, payload.Walk(stoppingVisitor))
+	assert.Equal(t, 3, stoppingVisitor.inputs)
+	assert.Equal(t, 1, stoppingVisitor.outputs)
+	assert.Equal(t, 0, stoppingVisitor.unlockBlocks)
+}
+
+func TestTransaction_DeserializeOversizedObjectCount(t *testing.T) {
+	const hugeCount = uint16(0xffff)
+
+	// a transaction essence without any inputs/outputs/payload data following the given count
+	essencePrefix := func(count uint16) []byte {
+		var buf bytes.Buffer
+		tpkg.Must(binary.Write(&buf, binary.LittleEndian, iotago.TransactionPayloadTypeID))
+		tpkg.Must(buf.WriteByte(iotago.TransactionEssenceNormal))
+		tpkg.Must(binary.Write(&buf, binary.LittleEndian, count))
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{
+			name: "inputs",
+			// padded to pass the transaction essence's minimum size check
+			data: append(essencePrefix(hugeCount), make([]byte, iotago.TransactionEssenceMinByteSize)...),
+		},
+		{
+			name: "outputs",
+			data: func() []byte {
+				var buf bytes.Buffer
+				buf.Write(essencePrefix(1))
+				_, inputData := tpkg.RandUTXOInput()
+				buf.Write(inputData)
+				tpkg.Must(binary.Write(&buf, binary.LittleEndian, hugeCount))
+				return buf.Bytes()
+			}(),
+		},
+		{
+			name: "unlock blocks",
+			data: func() []byte {
+				var buf bytes.Buffer
+				tpkg.Must(binary.Write(&buf, binary.LittleEndian, iotago.TransactionPayloadTypeID))
+				_, essenceData := tpkg.RandTransactionEssence()
+				buf.Write(essenceData)
+				tpkg.Must(binary.Write(&buf, binary.LittleEndian, hugeCount))
+				return buf.Bytes()
+			}(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&iotago.Transaction{}).Deserialize(tt.data, serializer.DeSeriModePerformValidation)
+			assert.True(t, errors.Is(err, serializer.ErrArrayValidationMaxElementsExceeded))
+
+			// without validation, the declared count must not cause an allocation up front either:
+			// deserialization fails as soon as the data runs out
+			_, err = (&iotago.Transaction{}).Deserialize(tt.data, serializer.DeSeriModeNoValidation)
+			assert.True(t, errors.Is(err, serializer.ErrDeserializationNotEnoughData))
+
+			for _, deSeriMode := range []serializer.DeSerializationMode{serializer.DeSeriModePerformValidation, serializer.DeSeriModeNoValidation} {
+				allocs := testing.AllocsPerRun(10, func() {
+					_, _ = (&iotago.Transaction{}).Deserialize(tt.data, deSeriMode)
+				})
+				assert.Less(t, allocs, float64(hugeCount))
+			}
+		})
+	}
+}
+
+func TestTxSemanticMinFee(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	feeAddr, _ := tpkg.RandEd25519Address()
+	outputAddr1, _ := tpkg.RandEd25519Address()
+
+	const minFee = 1_000
+
+	tests := []struct {
+		name     string
+		fee      uint64
+		validErr error
+	}{
+		{name: "ok - exact fee", fee: minFee},
+		{name: "ok - above fee", fee: minFee + 1},
+		{name: "err - below fee", fee: minFee - 1, validErr: iotago.ErrFeeBelowMinimum},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+			payload, err := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 10_000 - test.fee}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: feeAddr, Amount: test.fee}).
+				Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			assert.NoError(t, err)
+
+			inputUTXOs := iotago.InputToOutputMapping{
+				inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 10_000},
+			}
+
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticMinFee(feeAddr, minFee))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+
+	t.Run("err - fee address only moves its own funds", func(t *testing.T) {
+		feeIdentity := tpkg.RandEd25519PrivateKey()
+		feeInputAddr := iotago.AddressFromEd25519PubKey(feeIdentity.Public().(ed25519.PublicKey))
+		inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+		payload, err := iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &feeInputAddr, Input: inputUTXO1}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: &feeInputAddr, Amount: 10_000}).
+			Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &feeInputAddr, Keys: feeIdentity}))
+		assert.NoError(t, err)
+
+		inputUTXOs := iotago.InputToOutputMapping{
+			inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &feeInputAddr, Amount: 10_000},
+		}
+
+		semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticMinFee(&feeInputAddr, minFee))
+		assert.True(t, errors.Is(semanticErr, iotago.ErrFeeBelowMinimum))
+	})
+}
+
+func TestTransaction_OrphanedUnlockBlock(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 20},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 30},
+	}
+
+	build := func() *iotago.Transaction {
+		payload, err := iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+			Build(iotago.NewInMemoryAddressSigner(addrKeys))
+		assert.NoError(t, err)
+		return payload
+	}
+
+	t.Run("err - orphaned unlock block", func(t *testing.T) {
+		payload := build()
+		payload.UnlockBlocks = append(payload.UnlockBlocks, &iotago.ReferenceUnlockBlock{Reference: 0})
+		assert.True(t, errors.Is(payload.SyntacticallyValidate(), iotago.ErrUnlockBlocksMustMatchInputCount))
+		assert.True(t, errors.Is(payload.SemanticallyValidate(inputUTXOs), iotago.ErrUnlockBlocksMustMatchInputCount))
+	})
+
+	t.Run("err - input without unlock block", func(t *testing.T) {
+		payload := build()
+		payload.UnlockBlocks = payload.UnlockBlocks[:1]
+		assert.True(t, errors.Is(payload.SyntacticallyValidate(), iotago.ErrUnlockBlocksMustMatchInputCount))
+		assert.True(t, errors.Is(payload.SemanticallyValidate(inputUTXOs), iotago.ErrUnlockBlocksMustMatchInputCount))
+	})
+}
+
+func TestTransaction_LedgerMutation(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 4}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 40}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 10}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	inputs := iotago.OutputSet{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 20},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 30},
+	}
+	assert.NoError(t, payload.SemanticallyValidate(inputs))
+
+	mutation, err := payload.LedgerMutation(inputs)
+	assert.NoError(t, err)
+
+	var inputIDs iotago.UTXOInputIDs
+	for _, input := range payload.Essence.(*iotago.TransactionEssence).Inputs {
+		inputIDs = append(inputIDs, input.(*iotago.UTXOInput).ID())
+	}
+	assert.Equal(t, inputIDs, mutation.Consumed)
+
+	outputsSet, err := payload.OutputsSet()
+	assert.NoError(t, err)
+	assert.Equal(t, outputsSet, mutation.Created)
+
+	txID, err := payload.ID()
+	assert.NoError(t, err)
+	for i, output := range payload.Essence.(*iotago.TransactionEssence).Outputs {
+		utxoInput := &iotago.UTXOInput{TransactionID: *txID, TransactionOutputIndex: uint16(i)}
+		assert.Equal(t, output, mutation.Created[utxoInput.ID()])
+	}
+
+	delete(inputs, inputUTXO2.ID())
+	_, err = payload.LedgerMutation(inputs)
+	assert.True(t, errors.Is(err, iotago.ErrMissingUTXO))
+}
+
+func TestTransaction_PreflightUnlocks(t *testing.T) {
+	identityA := tpkg.RandEd25519PrivateKey()
+	addrA := iotago.AddressFromEd25519PubKey(identityA.Public().(ed25519.PublicKey))
+	identityB := tpkg.RandEd25519PrivateKey()
+	addrB := iotago.AddressFromEd25519PubKey(identityB.Public().(ed25519.PublicKey))
+
+	// signatures are not verified by PreflightUnlocks, only their public keys matter
+	sigBlock := func(identity ed25519.PrivateKey) *iotago.SignatureUnlockBlock {
+		sig := &iotago.Ed25519Signature{Signature: tpkg.Rand64ByteArray()}
+		copy(sig.PublicKey[:], identity.Public().(ed25519.PublicKey))
+		return &iotago.SignatureUnlockBlock{Signature: sig}
+	}
+
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO3 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	newTx := func(inputAddrs []*iotago.Ed25519Address, unlockBlocks serializer.Serializables) (*iotago.Transaction, iotago.OutputSet) {
+		inputs := iotago.OutputSet{}
+		essence := &iotago.TransactionEssence{}
+		for i, utxoInput := range []*iotago.UTXOInput{inputUTXO1, inputUTXO2, inputUTXO3}[:len(inputAddrs)] {
+			essence.Inputs = append(essence.Inputs, utxoInput)
+			inputs[utxoInput.ID()] = &iotago.SigLockedSingleOutput{Address: inputAddrs[i], Amount: 10}
+		}
+		return &iotago.Transaction{Essence: essence, UnlockBlocks: unlockBlocks}, inputs
+	}
+
+	tests := []struct {
+		name         string
+		inputAddrs   []*iotago.Ed25519Address
+		unlockBlocks serializer.Serializables
+		err          error
+	}{
+		{
+			name:         "ok",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrA, &addrB},
+			unlockBlocks: serializer.Serializables{sigBlock(identityA), &iotago.ReferenceUnlockBlock{Reference: 0}, sigBlock(identityB)},
+		},
+		{
+			name:         "err - reference to the signature of another address",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrB, &addrA},
+			unlockBlocks: serializer.Serializables{sigBlock(identityA), sigBlock(identityB), &iotago.ReferenceUnlockBlock{Reference: 1}},
+			err:          iotago.ErrInputSignatureUnlockBlockInvalid,
+		},
+		{
+			name:         "err - second signature unlock block for the same address",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrA, &addrB},
+			unlockBlocks: serializer.Serializables{sigBlock(identityA), sigBlock(identityA), sigBlock(identityB)},
+			err:          iotago.ErrInputSignatureUnlockBlockInvalid,
+		},
+		{
+			name:         "err - reference to a later unlock block",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrB, &addrB},
+			unlockBlocks: serializer.Serializables{sigBlock(identityA), &iotago.ReferenceUnlockBlock{Reference: 2}, sigBlock(identityB)},
+			err:          iotago.ErrRefUnlockBlockInvalidRef,
+		},
+		{
+			name:         "err - reference to a reference unlock block",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrA, &addrA},
+			unlockBlocks: serializer.Serializables{sigBlock(identityA), &iotago.ReferenceUnlockBlock{Reference: 0}, &iotago.ReferenceUnlockBlock{Reference: 1}},
+			err:          iotago.ErrRefUnlockBlockInvalidRef,
+		},
+		{
+			name:         "err - public key does not match the address",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrB},
+			unlockBlocks: serializer.Serializables{sigBlock(identityB), sigBlock(identityB)},
+			err:          iotago.ErrEd25519PubKeyAndAddrMismatch,
+		},
+		{
+			name:         "err - unlock block count mismatch",
+			inputAddrs:   []*iotago.Ed25519Address{&addrA, &addrB},
+			unlockBlocks: serializer.Serializables{sigBlock(identityA)},
+			err:          iotago.ErrUnlockBlocksMustMatchInputCount,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tx, inputs := newTx(test.inputAddrs, test.unlockBlocks)
+			err := tx.PreflightUnlocks(inputs)
+			if test.err != nil {
+				assert.True(t, errors.Is(err, test.err))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+
+	t.Run("err - missing UTXO", func(t *testing.T) {
+		tx, _ := newTx([]*iotago.Ed25519Address{&addrA}, serializer.Serializables{sigBlock(identityA)})
+		assert.True(t, errors.Is(tx.PreflightUnlocks(iotago.OutputSet{}), iotago.ErrMissingUTXO))
+	})
+}
+
+func TestTxSemanticMaxOutputConcentration(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	outputAddr2, _ := tpkg.RandEd25519Address()
+
+	tests := []struct {
+		name     string
+		amount1  uint64
+		amount2  uint64
+		validErr error
+	}{
+		{name: "ok - below threshold", amount1: 500, amount2: 500},
+		{name: "ok - at threshold", amount1: 750, amount2: 250},
+		{name: "err - over threshold", amount1: 751, amount2: 249, validErr: iotago.ErrOutputValueConcentrationExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+			payload, err := iotago.NewTransactionBuilder().
+				AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: test.amount1}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr2, Amount: test.amount2}).
+				Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			assert.NoError(t, err)
+
+			inputUTXOs := iotago.InputToOutputMapping{
+				inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 1_000},
+			}
+
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticMaxOutputConcentration(0.75))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestTransaction_DeserializationErrorOffset(t *testing.T) {
+	tx, txData := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+
+	// payload type, essence type, inputs, outputs count
+	firstOutputOffset := serializer.TypeDenotationByteSize + serializer.SmallTypeDenotationByteSize +
+		serializer.UInt16ByteSize + len(txEssence.Inputs)*iotago.UTXOInputSize + serializer.UInt16ByteSize
+	firstOutputAmountOffset := firstOutputOffset + iotago.Ed25519AddressSerializedBytesSize + serializer.SmallTypeDenotationByteSize
+
+	// the unlock blocks are at the end of the transaction
+	firstUnlockBlockOffset := len(txData) - len(tx.UnlockBlocks)*(serializer.SmallTypeDenotationByteSize+iotago.Ed25519SignatureSerializedBytesSize)
+	firstSignatureOffset := firstUnlockBlockOffset + serializer.SmallTypeDenotationByteSize
+
+	tests := []struct {
+		name       string
+		data       []byte
+		deSeriMode serializer.DeSerializationMode
+		offset     int
+	}{
+		{
+			name:       "with validation the output's min size check fails",
+			data:       txData[:firstOutputAmountOffset+3],
+			deSeriMode: serializer.DeSeriModePerformValidation,
+			offset:     firstOutputOffset,
+		},
+		{
+			name:       "without validation reading the amount fails",
+			data:       txData[:firstOutputAmountOffset+3],
+			deSeriMode: serializer.DeSeriModeNoValidation,
+			offset:     firstOutputAmountOffset,
+		},
+		{
+			name:       "with validation the unlock block's min size check fails",
+			data:       txData[:firstSignatureOffset+10],
+			deSeriMode: serializer.DeSeriModePerformValidation,
+			offset:     firstUnlockBlockOffset,
+		},
+		{
+			name:       "without validation the signature's size check fails",
+			data:       txData[:firstSignatureOffset+10],
+			deSeriMode: serializer.DeSeriModeNoValidation,
+			offset:     firstSignatureOffset,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := (&iotago.Transaction{}).Deserialize(tt.data, tt.deSeriMode)
+			assert.True(t, errors.Is(err, serializer.ErrDeserializationNotEnoughData))
+
+			var deSeriErr *iotago.DeserializationError
+			assert.True(t, errors.As(err, &deSeriErr))
+			assert.Equal(t, tt.offset, deSeriErr.Offset)
+		})
+	}
+}
+
+func TestTransaction_SemanticallyValidate_DeadSignature(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	otherIdentity := tpkg.RandEd25519PrivateKey()
+	otherAddr := iotago.AddressFromEd25519PubKey(otherIdentity.Public().(ed25519.PublicKey))
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	// a valid signature over the essence, but by an address which doesn't own the input
+	signingMessage, err := payload.Essence.(*iotago.TransactionEssence).SigningMessage()
+	assert.NoError(t, err)
+	deadSig, err := iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &otherAddr, Keys: otherIdentity}).Sign(&otherAddr, signingMessage)
+	assert.NoError(t, err)
+	payload.UnlockBlocks[0] = &iotago.SignatureUnlockBlock{Signature: deadSig}
+
+	inputUTXOs := iotago.OutputSet{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+	}
+
+	assert.NoError(t, payload.SyntacticallyValidate())
+	assert.True(t, errors.Is(payload.SemanticallyValidate(inputUTXOs), iotago.ErrEd25519PubKeyAndAddrMismatch))
+	assert.True(t, errors.Is(payload.PreflightUnlocks(inputUTXOs), iotago.ErrEd25519PubKeyAndAddrMismatch))
+}
+
+func TestTransactionHasher(t *testing.T) {
+	tx, txData := tpkg.RandTransaction()
+	txID, err := tx.ID()
+	assert.NoError(t, err)
+
+	for _, chunkSize := range []int{1, 7, 64, len(txData)} {
+		hasher := iotago.NewTransactionHasher()
+		for rest := txData; len(rest) > 0; {
+			n := chunkSize
+			if n > len(rest) {
+				n = len(rest)
+			}
+			_, err := hasher.Write(rest[:n])
+			assert.NoError(t, err)
+			rest = rest[n:]
+		}
+		assert.Equal(t, *txID, hasher.Sum())
+	}
+}
+
+func TestTransaction_Clone(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	txEssence.Payload, _ = tpkg.RandIndexation()
+	tx.UnlockBlocks = append(tx.UnlockBlocks, &iotago.ReferenceUnlockBlock{Reference: 0})
+
+	txData, err := tx.Serialize(serializer.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+
+	cpy, err := tx.Clone()
+	assert.NoError(t, err)
+	assert.Equal(t, tx, cpy)
+
+	cpyData, err := cpy.Serialize(serializer.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, txData, cpyData)
+
+	// mutating the clone leaves the original untouched
+	cpyEssence := cpy.Essence.(*iotago.TransactionEssence)
+	cpyEssence.Outputs[0].(*iotago.SigLockedSingleOutput).Amount++
+	cpyEssence.Outputs[0].(*iotago.SigLockedSingleOutput).Address.(*iotago.Ed25519Address)[0]++
+	cpyEssence.Inputs[0].(*iotago.UTXOInput).TransactionOutputIndex++
+	cpyEssence.Payload.(*iotago.Indexation).Index[0]++
+	cpy.UnlockBlocks[0].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0]++
+	cpy.UnlockBlocks[len(cpy.UnlockBlocks)-1].(*iotago.ReferenceUnlockBlock).Reference++
+
+	txDataAfter, err := tx.Serialize(serializer.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, txData, txDataAfter)
+
+	// objects of unsupported types can not be cloned
+	txEssence.Payload = &iotago.Milestone{}
+	_, err = tx.Clone()
+	assert.True(t, errors.Is(err, iotago.ErrUnsupportedPayloadType))
+
+	txEssence.Payload = nil
+	tx.UnlockBlocks[0] = &iotago.Ed25519Address{}
+	_, err = tx.Clone()
+	assert.True(t, errors.Is(err, iotago.ErrUnknownUnlockBlockType))
+}
+
+func TestTransaction_Equal(t *testing.T) {
+	tx, txData := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+
+	sameTx := &iotago.Transaction{}
+	_, err := sameTx.Deserialize(txData, serializer.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.True(t, tx.Equal(sameTx))
+	assert.True(t, txEssence.Equal(sameTx.Essence.(*iotago.TransactionEssence)))
+	cpy, err := tx.Clone()
+	assert.NoError(t, err)
+	assert.True(t, tx.Equal(cpy))
+
+	// flip one byte of the first output's amount
+	firstOutputAmountOffset := serializer.TypeDenotationByteSize + serializer.SmallTypeDenotationByteSize +
+		serializer.UInt16ByteSize + len(txEssence.Inputs)*iotago.UTXOInputSize + serializer.UInt16ByteSize +
+		serializer.SmallTypeDenotationByteSize + iotago.Ed25519AddressSerializedBytesSize
+	flippedData := append([]byte{}, txData...)
+	flippedData[firstOutputAmountOffset] ^= 0xff
+
+	flippedTx := &iotago.Transaction{}
+	_, err = flippedTx.Deserialize(flippedData, serializer.DeSeriModeNoValidation)
+	assert.NoError(t, err)
+	assert.NotEqual(t,
+		txEssence.Outputs[0].(*iotago.SigLockedSingleOutput).Amount,
+		flippedTx.Essence.(*iotago.TransactionEssence).Outputs[0].(*iotago.SigLockedSingleOutput).Amount,
+	)
+	assert.False(t, tx.Equal(flippedTx))
+	assert.False(t, txEssence.Equal(flippedTx.Essence.(*iotago.TransactionEssence)))
+
+	otherTx, _ := tpkg.RandTransaction()
+	assert.False(t, tx.Equal(otherTx))
+}
+
+func TestTxSemanticRequireChangeOutput(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	unrelatedAddr, _ := tpkg.RandEd25519Address()
+
+	type test struct {
+		name       string
+		changeAddr iotago.Address
+		builder    *iotago.TransactionBuilder
+		inputUTXOs iotago.InputToOutputMapping
+		validErr   error
+	}
+
+	newTest := func(name string, changeAddr iotago.Address, withChange bool, validErr error) test {
+		inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+		builder := iotago.NewTransactionBuilder().
+			AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1})
+		if withChange {
+			builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 30}).
+				AddOutput(&iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 20})
+		} else {
+			builder.AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50})
+		}
+		return test{
+			name:       name,
+			changeAddr: changeAddr,
+			builder:    builder,
+			inputUTXOs: iotago.InputToOutputMapping{
+				inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+			},
+			validErr: validErr,
+		}
+	}
+
+	tests := []test{
+		newTest("ok - change present", &inputAddr, true, nil),
+		newTest("ok - change address not consumed", unrelatedAddr, false, nil),
+		newTest("err - change absent", &inputAddr, false, iotago.ErrChangeOutputMissing),
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			payload, err := test.builder.Build(iotago.NewInMemoryAddressSigner(addrKeys))
+			assert.NoError(t, err)
+
+			semanticErr := payload.SemanticallyValidate(test.inputUTXOs, iotago.TxSemanticRequireChangeOutput(test.changeAddr))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestTxSemanticMinTotalValue(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+	}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 50}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		min      uint64
+		validErr error
+	}{
+		{name: "ok - below total value", min: 49},
+		{name: "ok - exactly total value", min: 50},
+		{name: "err - above total value", min: 51, validErr: iotago.ErrTotalValueBelowMinimum},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticMinTotalValue(test.min))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestTxSemanticMaxInputAge(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	addrKeys := iotago.AddressKeys{Address: &inputAddr, Keys: identityOne}
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXO1 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXO2 := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 1}
+	inputUTXOs := iotago.InputToOutputMapping{
+		inputUTXO1.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+		inputUTXO2.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50},
+	}
+
+	payload, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO1}).
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO2}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 100}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys))
+	assert.NoError(t, err)
+
+	const maxAge, confirmingMilestoneIndex = 100, 1000
+
+	tests := []struct {
+		name             string
+		originMilestones map[iotago.UTXOInputID]uint32
+		validErr         error
+	}{
+		{
+			name:             "ok - within max age",
+			originMilestones: map[iotago.UTXOInputID]uint32{inputUTXO1.ID(): 999, inputUTXO2.ID(): 950},
+		},
+		{
+			name:             "ok - exactly max age",
+			originMilestones: map[iotago.UTXOInputID]uint32{inputUTXO1.ID(): 900, inputUTXO2.ID(): 950},
+		},
+		{
+			name:             "err - beyond max age",
+			originMilestones: map[iotago.UTXOInputID]uint32{inputUTXO1.ID(): 950, inputUTXO2.ID(): 899},
+			validErr:         iotago.ErrInputTooOld,
+		},
+		{
+			name:             "err - origin milestone missing",
+			originMilestones: map[iotago.UTXOInputID]uint32{inputUTXO1.ID(): 950},
+			validErr:         iotago.ErrMissingInputOriginMilestone,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticMaxInputAge(maxAge, confirmingMilestoneIndex, test.originMilestones))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestTransaction_InputIDs(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+
+	inputIDs, err := tx.InputIDs()
+	assert.NoError(t, err)
+	assert.Len(t, inputIDs, len(txEssence.Inputs))
+	for i, input := range txEssence.Inputs {
+		assert.Equal(t, input.(*iotago.UTXOInput).ID(), inputIDs[i])
+	}
+
+	inputIDsMap := inputIDs.ToMap()
+	assert.Len(t, inputIDsMap, len(inputIDs))
+	for _, inputID := range inputIDs {
+		assert.Contains(t, inputIDsMap, inputID)
+	}
+
+	txEssence.Inputs = append(txEssence.Inputs, &iotago.TreasuryInput{})
+	_, err = tx.InputIDs()
+	assert.True(t, errors.Is(err, iotago.ErrUnknownInputType))
+}
+
+func TestTransaction_SemanticallyValidateAll(t *testing.T) {
+	var addrKeys []iotago.AddressKeys
+	inputUTXOs := iotago.InputToOutputMapping{}
+	builder := iotago.NewTransactionBuilder()
+	for i := 0; i < 2; i++ {
+		identity := tpkg.RandEd25519PrivateKey()
+		inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+		addrKeys = append(addrKeys, iotago.AddressKeys{Address: &inputAddr, Keys: identity})
+		inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+		inputUTXOs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50}
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO})
+	}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	tx, err := builder.
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 100}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys...))
+	assert.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, tx.SemanticallyValidateAll(inputUTXOs, iotago.TxSemanticMinTotalValue(100)))
+	})
+
+	t.Run("err - all failures are collected", func(t *testing.T) {
+		invalidTx, err := tx.Clone()
+		assert.NoError(t, err)
+		for _, ub := range invalidTx.UnlockBlocks {
+			ub.(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0] ^= 0xff
+		}
+		invalidTx.Essence.(*iotago.TransactionEssence).Outputs[0].(*iotago.SigLockedSingleOutput).Amount = 99
+
+		err = invalidTx.SemanticallyValidateAll(inputUTXOs, iotago.TxSemanticMinTotalValue(101), iotago.TxSemanticNoEmbeddedPayload())
+		var semValErrs *iotago.SemanticValidationErrors
+		assert.True(t, errors.As(err, &semValErrs))
+		assert.Len(t, semValErrs.Balance, 1)
+		assert.Len(t, semValErrs.SemanticValidationFuncs, 1)
+		assert.Len(t, semValErrs.Signatures, 2)
+		assert.Len(t, semValErrs.Errors(), 4)
+
+		assert.True(t, errors.Is(err, iotago.ErrInputOutputSumMismatch))
+		assert.True(t, errors.Is(err, iotago.ErrTotalValueBelowMinimum))
+		assert.True(t, errors.Is(err, iotago.ErrEd25519SignatureInvalid))
+		assert.False(t, errors.Is(err, iotago.ErrEmbeddedPayloadNotAllowed))
+
+		var semErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &semErr))
+		assert.Equal(t, 0, semErr.InputIndex)
+
+		// SemanticallyValidate still stops at the first failing check
+		assert.False(t, errors.As(invalidTx.SemanticallyValidate(inputUTXOs), &semValErrs))
+	})
+
+	t.Run("err - unresolvable inputs short-circuit", func(t *testing.T) {
+		err := tx.SemanticallyValidateAll(iotago.InputToOutputMapping{}, iotago.TxSemanticMinTotalValue(101))
+		assert.True(t, errors.Is(err, iotago.ErrMissingUTXO))
+		var semValErrs *iotago.SemanticValidationErrors
+		assert.False(t, errors.As(err, &semValErrs))
+	})
+}
+
+func TestTransaction_SemanticallyValidateContext(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	inputUTXOs := iotago.InputToOutputMapping{inputUTXO.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 100}}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	tx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 100}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}))
+	assert.NoError(t, err)
+
+	t.Run("ok", func(t *testing.T) {
+		assert.NoError(t, tx.SemanticallyValidateContext(context.Background(), inputUTXOs, iotago.TxSemanticMinTotalValue(100)))
+	})
+
+	t.Run("err - cancelled before validation", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.True(t, errors.Is(tx.SemanticallyValidateContext(ctx, inputUTXOs), context.Canceled))
+	})
+
+	t.Run("err - cancelled between semantic validation funcs", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		var calls int
+		cancelling := func(t *iotago.Transaction, utxos iotago.InputToOutputMapping) error {
+			calls++
+			cancel()
+			return nil
+		}
+		err := tx.SemanticallyValidateContext(ctx, inputUTXOs, cancelling, cancelling)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("err - cancelled between inputs", func(t *testing.T) {
+		builder := iotago.NewTransactionBuilder()
+		utxos := iotago.InputToOutputMapping{}
+		for i := 0; i < 2; i++ {
+			in := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+			utxos[in.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 100}
+			builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: in})
+		}
+		multiInputTx, err := builder.
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 200}).
+			Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}))
+		assert.NoError(t, err)
+
+		// the UTXO of the second input is missing, hence only the cancellation check before it can abort the validation
+		delete(utxos, multiInputTx.Essence.(*iotago.TransactionEssence).Inputs[1].(*iotago.UTXOInput).ID())
+
+		// done once checked before the validation and before the first input
+		ctx := &doneAfterCtx{Context: context.Background(), checksLeft: 2}
+		err = multiInputTx.SemanticallyValidateContext(ctx, utxos)
+		assert.True(t, errors.Is(err, context.Canceled))
+		assert.False(t, errors.Is(err, iotago.ErrMissingUTXO))
+	})
+}
+
+// a context.Context which is done after Err() has been called checksLeft times.
+type doneAfterCtx struct {
+	context.Context
+	checksLeft int
+}
+
+func (c *doneAfterCtx) Err() error {
+	if c.checksLeft == 0 {
+		return context.Canceled
+	}
+	c.checksLeft--
+	return nil
+}
+
+func TestSemanticValidationOrder(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	validTx, err := iotago.NewTransactionBuilder().
+		AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO}).
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 100}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}))
+	assert.NoError(t, err)
+
+	errSemValFunc := errors.New("failing semantic validation func")
+
+	// every step is broken at first and fixed one by one in the documented order,
+	// so that each step must be the one which fails next
+	broken := map[string]bool{}
+	for _, step := range iotago.SemanticValidationOrder() {
+		broken[step] = true
+	}
+	stepErrs := map[string]error{
+		"essence type":              iotago.ErrInvalidTransactionEssence,
+		"unlock blocks count":       iotago.ErrUnlockBlocksMustMatchInputCount,
+		"essence syntax":            iotago.ErrUnknownOutputType,
+		"inputs":                    iotago.ErrMissingUTXO,
+		"balance":                   iotago.ErrInputOutputSumMismatch,
+		"semantic validation funcs": errSemValFunc,
+		"signatures":                iotago.ErrEd25519SignatureInvalid,
+	}
+	assert.Len(t, stepErrs, len(broken))
+
+	validate := func() error {
+		tx, err := validTx.Clone()
+		if err != nil {
+			return err
+		}
+		utxos := iotago.InputToOutputMapping{inputUTXO.ID(): &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 100}}
+		var semValFuncs []iotago.SemanticValidationFunc
+
+		if broken["signatures"] {
+			tx.UnlockBlocks[0].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0] ^= 0xff
+		}
+		if broken["semantic validation funcs"] {
+			semValFuncs = append(semValFuncs, func(*iotago.Transaction, iotago.InputToOutputMapping) error {
+				return errSemValFunc
+			})
+		}
+		if broken["balance"] {
+			utxos[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 99}
+		}
+		if broken["inputs"] {
+			utxos = iotago.InputToOutputMapping{}
+		}
+		if broken["essence syntax"] {
+			essence := tx.Essence.(*iotago.TransactionEssence)
+			essence.Outputs = append(essence.Outputs, &iotago.UTXOInput{})
+		}
+		if broken["unlock blocks count"] {
+			tx.UnlockBlocks = nil
+		}
+		if broken["essence type"] {
+			tx.Essence = nil
+		}
+		return tx.SemanticallyValidate(utxos, semValFuncs...)
+	}
+
+	for _, step := range iotago.SemanticValidationOrder() {
+		err := validate()
+		assert.Truef(t, errors.Is(err, stepErrs[step]), "step %s: %v", step, err)
+		broken[step] = false
+	}
+	assert.NoError(t, validate())
+}
+
+func TestTxSemanticMaxUnlockRatio(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	addrOne := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	identityTwo := tpkg.RandEd25519PrivateKey()
+	addrTwo := iotago.AddressFromEd25519PubKey(identityTwo.Public().(ed25519.PublicKey))
+
+	// builds a transaction consuming one UTXO per given address
+	newTx := func(inputAddrs ...*iotago.Ed25519Address) (*iotago.Transaction, iotago.InputToOutputMapping) {
+		builder := iotago.NewTransactionBuilder()
+		inputUTXOs := iotago.InputToOutputMapping{}
+		for _, inputAddr := range inputAddrs {
+			inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+			inputUTXOs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: inputAddr, Amount: 50}
+			builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: inputAddr, Input: inputUTXO})
+		}
+		outputAddr, _ := tpkg.RandEd25519Address()
+		tx, err := builder.
+			AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: uint64(50 * len(inputAddrs))}).
+			Build(iotago.NewInMemoryAddressSigner(
+				iotago.AddressKeys{Address: &addrOne, Keys: identityOne},
+				iotago.AddressKeys{Address: &addrTwo, Keys: identityTwo},
+			))
+		assert.NoError(t, err)
+		return tx, inputUTXOs
+	}
+
+	unlockRatio := func(tx *iotago.Transaction) float64 {
+		essenceSize := tx.Essence.(*iotago.TransactionEssence).Size()
+		return float64(tx.Size()-iotago.TransactionBinSerializedMinSize-essenceSize) / float64(essenceSize)
+	}
+
+	dedupTx, dedupUTXOs := newTx(&addrOne, &addrOne)
+	distinctTx, distinctUTXOs := newTx(&addrOne, &addrTwo)
+	// the reference unlock block is much smaller than a second signature unlock block
+	assert.Less(t, unlockRatio(dedupTx), 1.0)
+	assert.Greater(t, unlockRatio(distinctTx), 1.0)
+
+	tests := []struct {
+		name     string
+		tx       *iotago.Transaction
+		utxos    iotago.InputToOutputMapping
+		maxRatio float64
+		wantErr  error
+	}{
+		{name: "ok - deduplicated signatures", tx: dedupTx, utxos: dedupUTXOs, maxRatio: 1.0},
+		{name: "ok - at the ratio", tx: distinctTx, utxos: distinctUTXOs, maxRatio: unlockRatio(distinctTx)},
+		{name: "err - distinct signatures over the ratio", tx: distinctTx, utxos: distinctUTXOs, maxRatio: 1.0, wantErr: iotago.ErrUnlockRatioExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.tx.SemanticallyValidate(tt.utxos, iotago.TxSemanticMaxUnlockRatio(tt.maxRatio))
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTxSemanticMaxInputsPerAddress(t *testing.T) {
+	identityOne := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identityOne.Public().(ed25519.PublicKey))
+	identityTwo := tpkg.RandEd25519PrivateKey()
+	inputAddr2 := iotago.AddressFromEd25519PubKey(identityTwo.Public().(ed25519.PublicKey))
+	signer := iotago.NewInMemoryAddressSigner(
+		iotago.AddressKeys{Address: &inputAddr, Keys: identityOne},
+		iotago.AddressKeys{Address: &inputAddr2, Keys: identityTwo},
+	)
+
+	outputAddr1, _ := tpkg.RandEd25519Address()
+	inputUTXOs := iotago.InputToOutputMapping{}
+	builder := iotago.NewTransactionBuilder()
+	for i, addr := range []*iotago.Ed25519Address{&inputAddr, &inputAddr, &inputAddr, &inputAddr2} {
+		inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: uint16(i)}
+		inputUTXOs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: addr, Amount: 50}
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: addr, Input: inputUTXO})
+	}
+	payload, err := builder.
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr1, Amount: 200}).
+		Build(signer)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		max      int
+		validErr error
+	}{
+		{name: "ok - above the inputs of any address", max: 4},
+		{name: "ok - exactly the inputs of the address with the most", max: 3},
+		{name: "err - an address consumes more inputs", max: 2, validErr: iotago.ErrMaxInputsPerAddressExceeded},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			semanticErr := payload.SemanticallyValidate(inputUTXOs, iotago.TxSemanticMaxInputsPerAddress(test.max))
+			if test.validErr != nil {
+				assert.True(t, errors.Is(semanticErr, test.validErr))
+				return
+			}
+			assert.NoError(t, semanticErr)
+		})
+	}
+}
+
+func TestSigningMessageFromTransactionBytes(t *testing.T) {
+	tx, txData := tpkg.RandTransaction()
+
+	expected, err := tx.Essence.(*iotago.TransactionEssence).SigningMessage()
+	assert.NoError(t, err)
+
+	signingMsg, err := iotago.SigningMessageFromTransactionBytes(txData, serializer.DeSeriModePerformValidation)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, signingMsg)
+
+	_, err = iotago.SigningMessageFromTransactionBytes(txData[:2], serializer.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, serializer.ErrDeserializationNotEnoughData))
+
+	wrongType := append([]byte{}, txData...)
+	binary.LittleEndian.PutUint32(wrongType, iotago.IndexationPayloadTypeID)
+	_, err = iotago.SigningMessageFromTransactionBytes(wrongType, serializer.DeSeriModePerformValidation)
+	assert.True(t, errors.Is(err, serializer.ErrDeserializationTypeMismatch))
+}
+
+func TestTransaction_MarshalJSONCanonical(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	tx.Essence.(*iotago.TransactionEssence).Payload = &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+
+	canonical, err := tx.MarshalJSONCanonical()
+	assert.NoError(t, err)
+
+	// a deep copy produces the same bytes
+	cpy, err := tx.Clone()
+	assert.NoError(t, err)
+	canonicalOfClone, err := cpy.MarshalJSONCanonical()
+	assert.NoError(t, err)
+	assert.Equal(t, canonical, canonicalOfClone)
+
+	// keys are sorted at every level
+	assert.True(t, bytes.HasPrefix(canonical, []byte(`{"essence":{"inputs":[{"transactionId":`)))
+
+	txFromJSON := &iotago.Transaction{}
+	assert.NoError(t, txFromJSON.UnmarshalJSON(canonical))
+	assert.True(t, tx.Equal(txFromJSON))
+}
+
+func TestTransaction_MissingInputs(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	inputIDs, err := tx.InputIDs()
+	assert.NoError(t, err)
+
+	inputs := iotago.OutputSet{}
+	for _, inputID := range inputIDs {
+		inputs[inputID], _ = tpkg.RandSigLockedSingleOutput(iotago.AddressEd25519)
+	}
+
+	missing, err := tx.MissingInputs(inputs)
+	assert.NoError(t, err)
+	assert.Empty(t, missing)
+
+	delete(inputs, inputIDs[0])
+	missing, err = tx.MissingInputs(inputs)
+	assert.NoError(t, err)
+	assert.Equal(t, iotago.UTXOInputIDs{inputIDs[0]}, missing)
+}
+
+func TestTransaction_SerializeVersioned(t *testing.T) {
+	tx, txData := tpkg.RandTransaction()
+
+	versioned, err := tx.SerializeVersioned()
+	assert.NoError(t, err)
+	assert.Equal(t, iotago.TransactionFormatVersion1, versioned[0])
+	assert.Equal(t, txData, versioned[1:])
+
+	txFromVersioned, err := iotago.DeserializeVersioned(versioned)
+	assert.NoError(t, err)
+	assert.True(t, tx.Equal(txFromVersioned))
+
+	versioned[0] = 0xff
+	_, err = iotago.DeserializeVersioned(versioned)
+	assert.True(t, errors.Is(err, iotago.ErrUnknownTransactionFormatVersion))
+
+	_, err = iotago.DeserializeVersioned(nil)
+	assert.True(t, errors.Is(err, serializer.ErrDeserializationNotEnoughData))
+}
+
+func TestTransaction_Size(t *testing.T) {
+	for i := 0; i < 20; i++ {
+		tx, txData := tpkg.RandTransaction()
+		txEssence := tx.Essence.(*iotago.TransactionEssence)
+		if i%2 == 0 {
+			txEssence.Payload, _ = tpkg.RandIndexation()
+			txEssence.Outputs = append(txEssence.Outputs, &iotago.SigLockedDustAllowanceOutput{Address: &iotago.Ed25519Address{}, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit})
+			tx.UnlockBlocks = append(tx.UnlockBlocks, &iotago.ReferenceUnlockBlock{Reference: 0})
+
+			var err error
+			txData, err = tx.Serialize(serializer.DeSeriModeNoValidation)
+			assert.NoError(t, err)
+		}
+
+		assert.Equal(t, len(txData), tx.Size())
+
+		txEssenceData, err := txEssence.Serialize(serializer.DeSeriModeNoValidation)
+		assert.NoError(t, err)
+		assert.Equal(t, len(txEssenceData), txEssence.Size())
+	}
+}
+
+// a payload which can neither tell its size nor be serialized.
+type unserializablePayload struct{}
+
+var errUnserializablePayload = errors.New("unserializable payload")
+
+func (unserializablePayload) Deserialize(_ []byte, _ serializer.DeSerializationMode) (int, error) {
+	return 0, errUnserializablePayload
+}
+
+func (unserializablePayload) Serialize(_ serializer.DeSerializationMode) ([]byte, error) {
+	return nil, errUnserializablePayload
+}
+
+func (unserializablePayload) MarshalJSON() ([]byte, error) {
+	return nil, errUnserializablePayload
+}
+
+func (unserializablePayload) UnmarshalJSON(_ []byte) error {
+	return errUnserializablePayload
+}
+
+func TestTransaction_SizeNilAndUnserializable(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	txEssence.Payload = nil
+	sizeWithoutPayload := txEssence.Size()
+
+	txEssence.Payload = (*iotago.Indexation)(nil)
+	assert.NotPanics(t, func() {
+		assert.Equal(t, sizeWithoutPayload, txEssence.Size())
+	})
+
+	txEssence.Payload = unserializablePayload{}
+	assert.PanicsWithError(t, "unable to determine the serialized size of iotago_test.unserializablePayload: unserializable payload", func() {
+		tx.Size()
+	})
+}
+
+func TestTransaction_CBOR(t *testing.T) {
+	tx, _ := tpkg.RandTransaction()
+	txEssence := tx.Essence.(*iotago.TransactionEssence)
+	txEssence.Payload = &iotago.Indexation{Index: []byte("index"), Data: tpkg.RandBytes(10)}
+
+	cborBytes, err := tx.MarshalCBOR()
+	assert.NoError(t, err)
+
+	txFromCBOR := &iotago.Transaction{}
+	assert.NoError(t, txFromCBOR.UnmarshalCBOR(cborBytes))
+	assert.True(t, tx.Equal(txFromCBOR))
+
+	essenceCBORBytes, err := txEssence.MarshalCBOR()
+	assert.NoError(t, err)
+
+	txEssenceFromCBOR := &iotago.TransactionEssence{}
+	assert.NoError(t, txEssenceFromCBOR.UnmarshalCBOR(essenceCBORBytes))
+	assert.True(t, txEssence.Equal(txEssenceFromCBOR))
+
+	// truncated data and trailing bytes must both be rejected
+	assert.True(t, errors.Is(txFromCBOR.UnmarshalCBOR(cborBytes[:len(cborBytes)-1]), iotago.ErrInvalidCBOR))
+	assert.True(t, errors.Is(txFromCBOR.UnmarshalCBOR(append(cborBytes, 0)), iotago.ErrInvalidCBOR))
+}
+
+func TestTransaction_RequiredSigners(t *testing.T) {
+	addrA, _ := tpkg.RandEd25519Address()
+	addrB, _ := tpkg.RandEd25519Address()
+
+	txEssence := &iotago.TransactionEssence{}
+	inputs := iotago.OutputSet{}
+	for _, addr := range []*iotago.Ed25519Address{addrA, addrB, addrA} {
+		utxoInput, _ := tpkg.RandUTXOInput()
+		txEssence.Inputs = append(txEssence.Inputs, utxoInput)
+		inputs[utxoInput.ID()] = &iotago.SigLockedSingleOutput{Address: addr, Amount: 10}
+	}
+	tx := &iotago.Transaction{Essence: txEssence}
+
+	signers, err := tx.RequiredSigners(inputs)
+	assert.NoError(t, err)
+	assert.Equal(t, []iotago.Address{addrA, addrB}, signers)
+
+	delete(inputs, txEssence.Inputs[1].(*iotago.UTXOInput).ID())
+	_, err = tx.RequiredSigners(inputs)
+	assert.True(t, errors.Is(err, iotago.ErrMissingUTXO))
+}
+
+func TestTransaction_ReferencedAddresses(t *testing.T) {
+	addrA, _ := tpkg.RandEd25519Address()
+	addrB, _ := tpkg.RandEd25519Address()
+
+	tx := &iotago.Transaction{Essence: &iotago.TransactionEssence{
+		Outputs: serializer.Serializables{
+			&iotago.SigLockedSingleOutput{Address: addrA, Amount: 10},
+			&iotago.SigLockedDustAllowanceOutput{Address: addrB, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			&iotago.SigLockedSingleOutput{Address: addrB, Amount: 20},
+		},
+	}}
+
+	addrs, err := tx.ReferencedAddresses()
+	assert.NoError(t, err)
+	assert.Equal(t, []iotago.Address{addrA, addrB}, addrs)
+}
+
+func TestTransaction_AmbiguousMappings(t *testing.T) {
+	addrA, _ := tpkg.RandEd25519Address()
+	addrB, _ := tpkg.RandEd25519Address()
+	addrC, _ := tpkg.RandEd25519Address()
+
+	newTx := func(inputOutputs []iotago.Output, outputs ...iotago.Output) (*iotago.Transaction, iotago.OutputSet) {
+		txEssence := &iotago.TransactionEssence{}
+		inputs := iotago.OutputSet{}
+		for _, inputOutput := range inputOutputs {
+			utxoInput, _ := tpkg.RandUTXOInput()
+			txEssence.Inputs = append(txEssence.Inputs, utxoInput)
+			inputs[utxoInput.ID()] = inputOutput
+		}
+		for _, output := range outputs {
+			txEssence.Outputs = append(txEssence.Outputs, output)
+		}
+		return &iotago.Transaction{Essence: txEssence}, inputs
+	}
+
+	t.Run("duplicate inputs carried on by an identical output", func(t *testing.T) {
+		tx, inputs := newTx(
+			[]iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: addrA, Amount: 10},
+				&iotago.SigLockedSingleOutput{Address: addrB, Amount: 10},
+				&iotago.SigLockedSingleOutput{Address: addrA, Amount: 10},
+			},
+			&iotago.SigLockedSingleOutput{Address: addrC, Amount: 20},
+			&iotago.SigLockedSingleOutput{Address: addrA, Amount: 10},
+		)
+		assert.Equal(t, []iotago.AmbiguityWarning{{InputIndices: []int{0, 2}, OutputIndices: []int{1}}}, tx.AmbiguousMappings(inputs))
+	})
+
+	t.Run("identical structure of another output type", func(t *testing.T) {
+		tx, inputs := newTx(
+			[]iotago.Output{
+				&iotago.SigLockedDustAllowanceOutput{Address: addrA, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+				&iotago.SigLockedDustAllowanceOutput{Address: addrA, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			},
+			&iotago.SigLockedSingleOutput{Address: addrA, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+			&iotago.SigLockedDustAllowanceOutput{Address: addrA, Amount: iotago.OutputSigLockedDustAllowanceOutputMinDeposit},
+		)
+		assert.Equal(t, []iotago.AmbiguityWarning{{InputIndices: []int{0, 1}, OutputIndices: []int{1}}}, tx.AmbiguousMappings(inputs))
+	})
+
+	t.Run("unambiguous", func(t *testing.T) {
+		tx, inputs := newTx(
+			[]iotago.Output{
+				&iotago.SigLockedSingleOutput{Address: addrA, Amount: 10},
+				&iotago.SigLockedSingleOutput{Address: addrA, Amount: 10},
+				&iotago.SigLockedSingleOutput{Address: addrB, Amount: 10},
+			},
+			&iotago.SigLockedSingleOutput{Address: addrB, Amount: 10},
+			&iotago.SigLockedSingleOutput{Address: addrC, Amount: 20},
+		)
+		assert.Empty(t, tx.AmbiguousMappings(inputs))
+	})
+}
+
+func TestTransaction_SemanticError(t *testing.T) {
+	identities := make([]ed25519.PrivateKey, 3)
+	var addrKeys []iotago.AddressKeys
+	inputUTXOs := iotago.InputToOutputMapping{}
+	builder := iotago.NewTransactionBuilder()
+	for i := range identities {
+		identities[i] = tpkg.RandEd25519PrivateKey()
+		inputAddr := iotago.AddressFromEd25519PubKey(identities[i].Public().(ed25519.PublicKey))
+		addrKeys = append(addrKeys, iotago.AddressKeys{Address: &inputAddr, Keys: identities[i]})
+		inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+		inputUTXOs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50}
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO})
+	}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	tx, err := builder.
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 150}).
+		Build(iotago.NewInMemoryAddressSigner(addrKeys...))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.SemanticallyValidate(inputUTXOs))
+
+	t.Run("invalid signature", func(t *testing.T) {
+		invalidTx, err := tx.Clone()
+		assert.NoError(t, err)
+		sig := invalidTx.UnlockBlocks[2].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature)
+		sig.Signature[0] ^= 0xff
+
+		err = invalidTx.SemanticallyValidate(inputUTXOs)
+		assert.True(t, errors.Is(err, iotago.ErrEd25519SignatureInvalid))
+		var semErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &semErr))
+		assert.Equal(t, 2, semErr.InputIndex)
+		assert.Equal(t, -1, semErr.OutputIndex)
+	})
+
+	t.Run("non canonical signature", func(t *testing.T) {
+		invalidTx, err := tx.Clone()
+		assert.NoError(t, err)
+		sig := invalidTx.UnlockBlocks[1].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature)
+		sig.Signature[63] = 0xff
+
+		err = invalidTx.SemanticallyValidate(inputUTXOs)
+		assert.True(t, errors.Is(err, iotago.ErrSignatureAndAddrIncompatible))
+		var semErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &semErr))
+		assert.Equal(t, 1, semErr.InputIndex)
+	})
+
+	t.Run("missing UTXO", func(t *testing.T) {
+		missingInputUTXOs := iotago.InputToOutputMapping{}
+		for id, output := range inputUTXOs {
+			missingInputUTXOs[id] = output
+		}
+		delete(missingInputUTXOs, tx.Essence.(*iotago.TransactionEssence).Inputs[1].(*iotago.UTXOInput).ID())
+
+		err := tx.SemanticallyValidate(missingInputUTXOs)
+		assert.True(t, errors.Is(err, iotago.ErrMissingUTXO))
+		var semErr *iotago.SemanticError
+		assert.True(t, errors.As(err, &semErr))
+		assert.Equal(t, 1, semErr.InputIndex)
+	})
+}
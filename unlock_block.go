@@ -30,6 +30,8 @@ var (
 	ErrRefUnlockBlockInvalidRef = errors.New("reference unlock block must point to a previous signature unlock block")
 	// ErrSigUnlockBlockHasNilSig gets returned if a signature unlock block contains a nil signature.
 	ErrSigUnlockBlockHasNilSig = errors.New("signature is nil")
+	// ErrReferentialUnlockInvalidRef gets returned if a referential unlock block does not reference a previous non-referential unlock block.
+	ErrReferentialUnlockInvalidRef = errors.New("referential unlock block must point to a previous non-referential unlock block")
 )
 
 // UnlockBlockSelector implements SerializableSelectorFunc for unlock block types.
@@ -52,8 +54,13 @@ type SignatureUnlockBlock struct {
 	Signature serializer.Serializable `json:"signature"`
 }
 
+// Size returns the size of the SignatureUnlockBlock in its serialized form.
+func (s *SignatureUnlockBlock) Size() int {
+	return serializer.SmallTypeDenotationByteSize + mustSerializedSize(s.Signature)
+}
+
 func (s *SignatureUnlockBlock) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(SignatureUnlockBlockMinSize, len(data)); err != nil {
@@ -70,7 +77,9 @@ func (s *SignatureUnlockBlock) Deserialize(data []byte, deSeriMode serializer.De
 		}).
 		ReadObject(func(seri serializer.Serializable) { s.Signature = seri }, deSeriMode, serializer.TypeDenotationByte, SignatureSelector, func(err error) error {
 			return fmt.Errorf("unable to deserialize signature within signature unlock block: %w", err)
-		}).Done()
+		}).
+		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (s *SignatureUnlockBlock) Serialize(deSeriMode serializer.DeSerializationMode) ([]byte, error) {
@@ -109,19 +118,37 @@ func (s *SignatureUnlockBlock) UnmarshalJSON(bytes []byte) error {
 	return nil
 }
 
+// ReferentialUnlockBlock is an unlock block which unlocks through another unlock block it references.
+type ReferentialUnlockBlock interface {
+	serializer.Serializable
+	// Ref returns the index of the referenced unlock block.
+	Ref() uint16
+}
+
 // ReferenceUnlockBlock is an unlock block which references a previous unlock block.
 type ReferenceUnlockBlock struct {
 	// The other unlock block this reference unlock block references to.
 	Reference uint16 `json:"reference"`
 }
 
+// Ref returns the index of the referenced unlock block.
+func (r *ReferenceUnlockBlock) Ref() uint16 {
+	return r.Reference
+}
+
+// Size returns the size of the ReferenceUnlockBlock in its serialized form.
+func (r *ReferenceUnlockBlock) Size() int {
+	return ReferenceUnlockBlockSize
+}
+
 func (r *ReferenceUnlockBlock) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
+	// the length is checked regardless of the mode as the fixed size fields are sliced out of data
+	if err := serializer.CheckMinByteLength(ReferenceUnlockBlockSize, len(data)); err != nil {
+		return 0, deserializationError(0, fmt.Errorf("invalid reference unlock block bytes: %w", err))
+	}
 	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
-		if err := serializer.CheckMinByteLength(ReferenceUnlockBlockSize, len(data)); err != nil {
-			return 0, fmt.Errorf("invalid reference unlock block bytes: %w", err)
-		}
 		if err := serializer.CheckTypeByte(data, UnlockBlockReference); err != nil {
-			return 0, fmt.Errorf("unable to deserialize reference unlock block: %w", err)
+			return 0, deserializationError(0, fmt.Errorf("unable to deserialize reference unlock block: %w", err))
 		}
 	}
 	data = data[serializer.SmallTypeDenotationByteSize:]
@@ -202,6 +229,71 @@ func UnlockBlocksSigUniqueAndRefValidator() UnlockBlockValidatorFunc {
 	}
 }
 
+// UnlockBlocksRefDepthValidator returns a validator which checks that every ReferentialUnlockBlock
+// references a previous unlock block which is not referential itself, so that every reference
+// resolves to the actual unlocking data in a single hop.
+func UnlockBlocksRefDepthValidator() UnlockBlockValidatorFunc {
+	// maps the index of every seen unlock block to whether it is referential
+	seenReferential := map[int]bool{}
+
+	return func(index int, unlockBlock serializer.Serializable) error {
+		refUnlockBlock, isReferential := unlockBlock.(ReferentialUnlockBlock)
+		seenReferential[index] = isReferential
+		if !isReferential {
+			return nil
+		}
+
+		reference := int(refUnlockBlock.Ref())
+		targetIsReferential, has := seenReferential[reference]
+		switch {
+		case !has || reference >= index:
+			return fmt.Errorf("%w: %d references unlock block %d which does not precede it", ErrReferentialUnlockInvalidRef, index, reference)
+		case targetIsReferential:
+			return fmt.Errorf("%w: %d references unlock block %d which is referential itself", ErrReferentialUnlockInvalidRef, index, reference)
+		}
+		return nil
+	}
+}
+
+// UnlockBlocks is a slice of unlock blocks as held by a Transaction.
+type UnlockBlocks serializer.Serializables
+
+// UnlockTree is a SignatureUnlockBlock together with the indices of the ReferenceUnlockBlock(s) referencing it.
+type UnlockTree struct {
+	// The index of the SignatureUnlockBlock.
+	Index int
+	// The SignatureUnlockBlock at the root of the tree.
+	SignatureUnlockBlock *SignatureUnlockBlock
+	// The indices of the ReferenceUnlockBlock(s) referencing the SignatureUnlockBlock, in ascending order.
+	References []int
+}
+
+// Forest returns the unlock blocks as a forest of UnlockTree(s), one for every SignatureUnlockBlock in
+// the order of their occurrence. Forest returns an error if a ReferenceUnlockBlock does not reference
+// a previous SignatureUnlockBlock, which also rules out cycles, or if an unlock block is of an unknown type.
+func (u UnlockBlocks) Forest() ([]UnlockTree, error) {
+	var forest []UnlockTree
+	// maps the index of a signature unlock block to the position of its tree within the forest
+	treeIndices := map[int]int{}
+	for i, unlockBlock := range u {
+		switch x := unlockBlock.(type) {
+		case *SignatureUnlockBlock:
+			treeIndices[i] = len(forest)
+			forest = append(forest, UnlockTree{Index: i, SignatureUnlockBlock: x})
+		case *ReferenceUnlockBlock:
+			reference := int(x.Reference)
+			treeIndex, has := treeIndices[reference]
+			if !has {
+				return nil, fmt.Errorf("%w: %d references unlock block %d which is no previous signature unlock block", ErrRefUnlockBlockInvalidRef, i, reference)
+			}
+			forest[treeIndex].References = append(forest[treeIndex].References, i)
+		default:
+			return nil, fmt.Errorf("%w: unlock block at index %d is of unknown type %T", ErrUnknownUnlockBlockType, i, x)
+		}
+	}
+	return forest, nil
+}
+
 // ValidateUnlockBlocks validates the unlock blocks by running them against the given UnlockBlockValidatorFunc.
 func ValidateUnlockBlocks(unlockBlocks serializer.Serializables, funcs ...UnlockBlockValidatorFunc) error {
 	for i, unlockBlock := range unlockBlocks {
@@ -178,6 +178,48 @@ func TestUnlockBlockValidatorFunc(t *testing.T) {
 				}(),
 			}, funcs: []iotago.UnlockBlockValidatorFunc{iotago.UnlockBlocksSigUniqueAndRefValidator()}}, true,
 		},
+		{
+			"ok - single hop references",
+			args{inputs: []serializer.Serializable{
+				func() serializer.Serializable {
+					block, _ := tpkg.RandEd25519SignatureUnlockBlock()
+					return block
+				}(),
+				func() serializer.Serializable {
+					return &iotago.ReferenceUnlockBlock{Reference: 0}
+				}(),
+				func() serializer.Serializable {
+					return &iotago.ReferenceUnlockBlock{Reference: 0}
+				}(),
+			}, funcs: []iotago.UnlockBlockValidatorFunc{iotago.UnlockBlocksRefDepthValidator()}}, false,
+		},
+		{
+			"chain of references",
+			args{inputs: []serializer.Serializable{
+				func() serializer.Serializable {
+					block, _ := tpkg.RandEd25519SignatureUnlockBlock()
+					return block
+				}(),
+				func() serializer.Serializable {
+					return &iotago.ReferenceUnlockBlock{Reference: 0}
+				}(),
+				func() serializer.Serializable {
+					return &iotago.ReferenceUnlockBlock{Reference: 1}
+				}(),
+			}, funcs: []iotago.UnlockBlockValidatorFunc{iotago.UnlockBlocksRefDepthValidator()}}, true,
+		},
+		{
+			"reference to itself",
+			args{inputs: []serializer.Serializable{
+				func() serializer.Serializable {
+					block, _ := tpkg.RandEd25519SignatureUnlockBlock()
+					return block
+				}(),
+				func() serializer.Serializable {
+					return &iotago.ReferenceUnlockBlock{Reference: 1}
+				}(),
+			}, funcs: []iotago.UnlockBlockValidatorFunc{iotago.UnlockBlocksRefDepthValidator()}}, true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -187,3 +229,58 @@ func TestUnlockBlockValidatorFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestUnlockBlocks_Forest(t *testing.T) {
+	sigBlock1, _ := tpkg.RandEd25519SignatureUnlockBlock()
+	sigBlock2, _ := tpkg.RandEd25519SignatureUnlockBlock()
+
+	tests := []struct {
+		name         string
+		unlockBlocks iotago.UnlockBlocks
+		want         []iotago.UnlockTree
+		wantErr      error
+	}{
+		{
+			name: "ok",
+			unlockBlocks: iotago.UnlockBlocks{
+				sigBlock1,
+				&iotago.ReferenceUnlockBlock{Reference: 0},
+				sigBlock2,
+				&iotago.ReferenceUnlockBlock{Reference: 0},
+				&iotago.ReferenceUnlockBlock{Reference: 2},
+			},
+			want: []iotago.UnlockTree{
+				{Index: 0, SignatureUnlockBlock: sigBlock1, References: []int{1, 3}},
+				{Index: 2, SignatureUnlockBlock: sigBlock2, References: []int{4}},
+			},
+		},
+		{
+			name: "fail - reference to reference",
+			unlockBlocks: iotago.UnlockBlocks{
+				sigBlock1,
+				&iotago.ReferenceUnlockBlock{Reference: 0},
+				&iotago.ReferenceUnlockBlock{Reference: 1},
+			},
+			wantErr: iotago.ErrRefUnlockBlockInvalidRef,
+		},
+		{
+			name: "fail - dangling reference",
+			unlockBlocks: iotago.UnlockBlocks{
+				&iotago.ReferenceUnlockBlock{Reference: 1},
+				sigBlock1,
+			},
+			wantErr: iotago.ErrRefUnlockBlockInvalidRef,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			forest, err := tt.unlockBlocks.Forest()
+			if tt.wantErr != nil {
+				assert.True(t, errors.Is(err, tt.wantErr))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, forest)
+		})
+	}
+}
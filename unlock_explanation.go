@@ -0,0 +1,134 @@
+package iotago
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInputIndexOutOfRange gets returned if an input index does not refer to an input of the transaction.
+	ErrInputIndexOutOfRange = errors.New("input index out of range")
+)
+
+// UnlockExplanation describes how an input of a Transaction is unlocked and why it isn't, if it isn't.
+type UnlockExplanation struct {
+	// The index of the explained input.
+	InputIndex int
+	// The ID of the UTXO the input consumes.
+	UTXOInputID UTXOInputID
+	// The address which must be unlocked in order to consume the UTXO.
+	Target Address
+	// The index of the SignatureUnlockBlock the input's unlock block resolves to or -1 if it does not resolve to any.
+	SignatureUnlockBlockIndex int
+	// Whether the input is unlocked.
+	Unlocked bool
+	// The reasons why the input is not unlocked, empty if it is.
+	Reasons []error
+}
+
+// ExplainUnlock explains whether and why the input at the given index is unlocked by the unlock blocks of the Transaction.
+// Unlike SemanticallyValidate, it does not stop at the first failing check but collects all reasons which prevent
+// the input from being unlocked. An error is only returned if the target of the input can not be determined.
+// ExplainUnlock is purely diagnostic and must not be used in place of SemanticallyValidate.
+func (t *Transaction) ExplainUnlock(inputs OutputSet, inputIndex int) (*UnlockExplanation, error) {
+	txEssence, ok := t.Essence.(*TransactionEssence)
+	if !ok {
+		return nil, fmt.Errorf("%w: transaction is not *TransactionEssence", ErrInvalidTransactionEssence)
+	}
+
+	if inputIndex < 0 || inputIndex >= len(txEssence.Inputs) {
+		return nil, fmt.Errorf("%w: index %d, transaction has %d inputs", ErrInputIndexOutOfRange, inputIndex, len(txEssence.Inputs))
+	}
+
+	addr, utxoID, err := inputTargetAddr(txEssence, inputs, inputIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation := &UnlockExplanation{InputIndex: inputIndex, UTXOInputID: utxoID, Target: addr, SignatureUnlockBlockIndex: -1}
+	defer func() { explanation.Unlocked = len(explanation.Reasons) == 0 }()
+
+	sigBlockIndex, err := t.resolveSignatureUnlockBlockIndex(inputIndex)
+	if err != nil {
+		explanation.Reasons = append(explanation.Reasons, err)
+		return explanation, nil
+	}
+	explanation.SignatureUnlockBlockIndex = sigBlockIndex
+
+	// inputs of the same address must all be unlocked through the same signature unlock block
+	for i := 0; i < inputIndex; i++ {
+		otherAddr, _, err := inputTargetAddr(txEssence, inputs, i)
+		if err != nil || otherAddr.String() != addr.String() {
+			continue
+		}
+		if otherSigBlockIndex, err := t.resolveSignatureUnlockBlockIndex(i); err == nil && otherSigBlockIndex != sigBlockIndex {
+			explanation.Reasons = append(explanation.Reasons, fmt.Errorf("%w: input %d of the same address uses signature unlock block %d instead of %d", ErrInputSignatureUnlockBlockInvalid, i, otherSigBlockIndex, sigBlockIndex))
+		}
+	}
+
+	sigBlock := t.UnlockBlocks[sigBlockIndex].(*SignatureUnlockBlock)
+	if sigBlock.Signature == nil {
+		explanation.Reasons = append(explanation.Reasons, fmt.Errorf("%w: at index %d", ErrSigUnlockBlockHasNilSig, sigBlockIndex))
+		return explanation, nil
+	}
+
+	txEssenceBytes, err := txEssence.SigningMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	sigValidF, err := createSigValidationFunc(inputIndex, sigBlock.Signature, sigBlockIndex, txEssenceBytes, addr, DefaultSignatureVerifier)
+	if err != nil {
+		explanation.Reasons = append(explanation.Reasons, err)
+		return explanation, nil
+	}
+	if err := sigValidF(); err != nil {
+		explanation.Reasons = append(explanation.Reasons, err)
+	}
+
+	return explanation, nil
+}
+
+// returns the address the UTXO consumed by the input at the given index is locked to.
+func inputTargetAddr(txEssence *TransactionEssence, inputs OutputSet, inputIndex int) (Address, UTXOInputID, error) {
+	in, ok := txEssence.Inputs[inputIndex].(*UTXOInput)
+	if !ok {
+		return nil, UTXOInputID{}, fmt.Errorf("%w: unsupported input type at index %d", ErrUnknownInputType, inputIndex)
+	}
+
+	utxoID := in.ID()
+	utxo, has := inputs[utxoID]
+	if !has {
+		return nil, utxoID, fmt.Errorf("%w: UTXO for ID %v is not provided (input at index %d)", ErrMissingUTXO, utxoID, inputIndex)
+	}
+
+	addr, _, err := outputAddrAndDeposit(utxo)
+	if err != nil {
+		return nil, utxoID, fmt.Errorf("unable to get address of UTXO %v (input at index %d): %w", utxoID, inputIndex, err)
+	}
+	return addr, utxoID, nil
+}
+
+// resolves the index of the SignatureUnlockBlock unlocking the input at the given index.
+// Unlike signatureUnlockBlock, it does not rely on the Transaction being syntactically valid.
+func (t *Transaction) resolveSignatureUnlockBlockIndex(inputIndex int) (int, error) {
+	if inputIndex >= len(t.UnlockBlocks) {
+		return 0, fmt.Errorf("%w: no unlock block for input %d", ErrUnlockBlocksMustMatchInputCount, inputIndex)
+	}
+
+	switch ub := t.UnlockBlocks[inputIndex].(type) {
+	case *SignatureUnlockBlock:
+		return inputIndex, nil
+	case *ReferenceUnlockBlock:
+		reference := int(ub.Reference)
+		if reference >= inputIndex {
+			return 0, fmt.Errorf("%w: %d references unlock block %d which does not precede it", ErrRefUnlockBlockInvalidRef, inputIndex, reference)
+		}
+		if _, isSigBlock := t.UnlockBlocks[reference].(*SignatureUnlockBlock); !isSigBlock {
+			return 0, fmt.Errorf("%w: %d references unlock block %d which is no signature unlock block", ErrRefUnlockBlockInvalidRef, inputIndex, reference)
+		}
+		return reference, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported unlock block type at index %d", ErrUnknownUnlockBlockType, inputIndex)
+	}
+}
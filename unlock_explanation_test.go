@@ -0,0 +1,69 @@
+package iotago_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/iotaledger/iota.go/v2"
+	"github.com/iotaledger/iota.go/v2/ed25519"
+	"github.com/iotaledger/iota.go/v2/tpkg"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_ExplainUnlock(t *testing.T) {
+	identity := tpkg.RandEd25519PrivateKey()
+	inputAddr := iotago.AddressFromEd25519PubKey(identity.Public().(ed25519.PublicKey))
+	inputs := iotago.OutputSet{}
+	builder := iotago.NewTransactionBuilder()
+	for i := 0; i < 2; i++ {
+		inputUTXO := &iotago.UTXOInput{TransactionID: tpkg.Rand32ByteArray(), TransactionOutputIndex: 0}
+		inputs[inputUTXO.ID()] = &iotago.SigLockedSingleOutput{Address: &inputAddr, Amount: 50}
+		builder.AddInput(&iotago.ToBeSignedUTXOInput{Address: &inputAddr, Input: inputUTXO})
+	}
+	outputAddr, _ := tpkg.RandEd25519Address()
+	tx, err := builder.
+		AddOutput(&iotago.SigLockedSingleOutput{Address: outputAddr, Amount: 100}).
+		Build(iotago.NewInMemoryAddressSigner(iotago.AddressKeys{Address: &inputAddr, Keys: identity}))
+	assert.NoError(t, err)
+
+	t.Run("ok - unlocked through reference", func(t *testing.T) {
+		explanation, err := tx.ExplainUnlock(inputs, 1)
+		assert.NoError(t, err)
+		assert.True(t, explanation.Unlocked)
+		assert.Empty(t, explanation.Reasons)
+		assert.Equal(t, 0, explanation.SignatureUnlockBlockIndex)
+		assert.Equal(t, inputAddr.String(), explanation.Target.String())
+	})
+
+	t.Run("not unlocked - invalid signature", func(t *testing.T) {
+		invalidTx, err := tx.Clone()
+		assert.NoError(t, err)
+		invalidTx.UnlockBlocks[0].(*iotago.SignatureUnlockBlock).Signature.(*iotago.Ed25519Signature).Signature[0] ^= 0xff
+		explanation, err := invalidTx.ExplainUnlock(inputs, 1)
+		assert.NoError(t, err)
+		assert.False(t, explanation.Unlocked)
+		assert.Len(t, explanation.Reasons, 1)
+		assert.True(t, errors.Is(explanation.Reasons[0], iotago.ErrEd25519SignatureInvalid))
+	})
+
+	t.Run("not unlocked - reference to itself", func(t *testing.T) {
+		invalidTx, err := tx.Clone()
+		assert.NoError(t, err)
+		invalidTx.UnlockBlocks[1] = &iotago.ReferenceUnlockBlock{Reference: 1}
+		explanation, err := invalidTx.ExplainUnlock(inputs, 1)
+		assert.NoError(t, err)
+		assert.False(t, explanation.Unlocked)
+		assert.Equal(t, -1, explanation.SignatureUnlockBlockIndex)
+		assert.True(t, errors.Is(explanation.Reasons[0], iotago.ErrRefUnlockBlockInvalidRef))
+	})
+
+	t.Run("err - missing UTXO", func(t *testing.T) {
+		_, err := tx.ExplainUnlock(iotago.OutputSet{}, 0)
+		assert.True(t, errors.Is(err, iotago.ErrMissingUTXO))
+	})
+
+	t.Run("err - input index out of range", func(t *testing.T) {
+		_, err := tx.ExplainUnlock(inputs, 2)
+		assert.True(t, errors.Is(err, iotago.ErrInputIndexOutOfRange))
+	})
+}
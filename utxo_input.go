@@ -39,6 +39,15 @@ func (utxoInputIDs UTXOInputIDs) ToHex() []string {
 	return ids
 }
 
+// ToMap converts the UTXOInputIDs to a set for lookups.
+func (utxoInputIDs UTXOInputIDs) ToMap() map[UTXOInputID]struct{} {
+	m := make(map[UTXOInputID]struct{}, len(utxoInputIDs))
+	for _, id := range utxoInputIDs {
+		m[id] = struct{}{}
+	}
+	return m
+}
+
 // UTXOInput references an unspent transaction output by the Transaction's ID and the corresponding index of the output.
 type UTXOInput struct {
 	// The transaction ID of the referenced transaction.
@@ -55,8 +64,13 @@ func (u *UTXOInput) ID() UTXOInputID {
 	return id
 }
 
+// Size returns the size of the UTXOInput in its serialized form.
+func (u *UTXOInput) Size() int {
+	return UTXOInputSize
+}
+
 func (u *UTXOInput) Deserialize(data []byte, deSeriMode serializer.DeSerializationMode) (int, error) {
-	return serializer.NewDeserializer(data).
+	bytesRead, err := serializer.NewDeserializer(data).
 		AbortIf(func(err error) error {
 			if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
 				if err := serializer.CheckMinByteLength(UTXOInputSize, len(data)); err != nil {
@@ -86,6 +100,7 @@ func (u *UTXOInput) Deserialize(data []byte, deSeriMode serializer.DeSerializati
 			return nil
 		}).
 		Done()
+	return bytesRead, deserializationError(bytesRead, err)
 }
 
 func (u *UTXOInput) Serialize(deSeriMode serializer.DeSerializationMode) (data []byte, err error) {
@@ -109,6 +124,18 @@ func (u *UTXOInput) Serialize(deSeriMode serializer.DeSerializationMode) (data [
 		}).Serialize()
 }
 
+// appends the serialized form of the UTXOInput to dst, which equals the output of Serialize().
+func (u *UTXOInput) appendSerialized(dst []byte, deSeriMode serializer.DeSerializationMode) ([]byte, error) {
+	if deSeriMode.HasMode(serializer.DeSeriModePerformValidation) {
+		if err := utxoInputRefBoundsValidator(-1, u); err != nil {
+			return dst, fmt.Errorf("%w: unable to serialize UTXO input", err)
+		}
+	}
+	dst = append(dst, InputUTXO)
+	dst = append(dst, u.TransactionID[:]...)
+	return appendUint16(dst, u.TransactionOutputIndex), nil
+}
+
 func (u *UTXOInput) MarshalJSON() ([]byte, error) {
 	jUTXOInput := &jsonUTXOInput{}
 	jUTXOInput.TransactionID = hex.EncodeToString(u.TransactionID[:])